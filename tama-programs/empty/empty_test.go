@@ -0,0 +1,9 @@
+//go:build tamago && riscv64
+
+package main
+
+import "testing"
+
+func TestEmpty(t *testing.T) {
+	main()
+}
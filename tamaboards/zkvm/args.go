@@ -0,0 +1,100 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+// ErrArgs is returned when the input payload starts with a malformed argument
+// header.
+var ErrArgs = errors.New("zkvm: invalid argument header")
+
+//go:linkname argc runtime.argc
+var argc int32
+
+//go:linkname argv runtime.argv
+var argv **byte
+
+// argsTable holds the argument and environment pointers passed to the
+// runtime, each list is nil terminated.
+var argsTable [MAX_ARGS + 2]*byte
+
+var argsOffsets [MAX_ARGS]int
+
+// argsLength is the length of the argument header found at the start of the
+// input payload.
+var argsLength int
+
+// argsInvalid is set when the argument header is malformed.
+var argsInvalid bool
+
+// hwinit0 is called by the runtime before its initialization, it installs
+// runtime.Exit and passes the optional argument header of the input payload
+// to the runtime as argc/argv.
+//
+//go:linkname hwinit0 runtime.hwinit0
+func hwinit0() {
+	// installed first to terminate the emulator on early fatal errors
+	// (e.g. an invalid ZKVM_SEED)
+	runtime.Exit = shutdown
+
+	n := *(*uint64)(unsafe.Pointer(uintptr(INPUT_ADDR + INPUT_SIZE_OFFSET)))
+
+	if n > MAX_INPUT-INPUT_DATA_OFFSET {
+		return
+	}
+
+	b := unsafe.Slice((*byte)(unsafe.Pointer(uintptr(INPUT_ADDR+INPUT_DATA_OFFSET))), n)
+	c, envc, hdr, ok := parseArgs(b, &argsOffsets)
+
+	if !ok {
+		argsInvalid = true
+		return
+	}
+
+	if hdr == 0 {
+		return
+	}
+
+	// arguments, nil, environment, nil (see runtime.goenvs)
+	for i := 0; i < c; i++ {
+		argsTable[i] = &b[argsOffsets[i]]
+	}
+
+	for i := 0; i < envc; i++ {
+		argsTable[c+1+i] = &b[argsOffsets[c+i]]
+	}
+
+	argsLength = hdr
+	argc = int32(c)
+	argv = &argsTable[0]
+}
+
+//go:linkname buildGetenv runtime.buildGetenv
+func buildGetenv(key string) string
+
+// getenv returns the value of key in the environment of the input argument
+// header or, when not found, in the link time one. Unlike os.Getenv it can be
+// used before the runtime is initialized.
+func getenv(key string) string {
+	for _, p := range argsTable[argc+1:] {
+		if p == nil {
+			break
+		}
+
+		n := 0
+
+		for *(*byte)(unsafe.Add(unsafe.Pointer(p), n)) != 0 {
+			n++
+		}
+
+		if kv := unsafe.String(p, n); len(kv) > len(key) && kv[len(key)] == '=' && kv[:len(key)] == key {
+			return kv[len(key)+1:]
+		}
+	}
+
+	return buildGetenv(key)
+}
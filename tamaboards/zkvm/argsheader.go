@@ -0,0 +1,112 @@
+package zkvm
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// The program input payload can optionally start with an argument header,
+// carrying the command-line arguments (os.Args) and environment variables
+// (os.Environ) of the program:
+//
+//	+0  uint64  ARGS_MAGIC
+//	+8  uint32  number of arguments (argc), program name included
+//	+12 uint32  number of environment variables (envc)
+//	+16         argc arguments, then envc KEY=value strings, each NUL
+//	            terminated, zero padded to a multiple of 8 bytes
+//
+// The header is stripped from the payload returned by Input().
+const (
+	// ARGS_MAGIC identifies the argument header ("GOARGS" followed by
+	// format version 1, little-endian).
+	ARGS_MAGIC = 0x0001_5347_5241_4f47
+	// ARGS_DATA_OFFSET is the offset of the first string within the
+	// argument header.
+	ARGS_DATA_OFFSET = 16
+	// MAX_ARGS is the maximum number of strings (arguments and environment
+	// variables) in the argument header.
+	MAX_ARGS = 64
+)
+
+// EncodeArgs returns payload prefixed with an argument header carrying args
+// and env, as expected by the board when supplied as program input.
+func EncodeArgs(args []string, env []string, payload []byte) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("zkvm: missing program name")
+	}
+
+	if len(args)+len(env) > MAX_ARGS {
+		return nil, errors.New("zkvm: too many arguments")
+	}
+
+	for _, s := range append(args[:len(args):len(args)], env...) {
+		if strings.IndexByte(s, 0) >= 0 {
+			return nil, errors.New("zkvm: argument contains NUL")
+		}
+	}
+
+	for _, kv := range env {
+		if strings.IndexByte(kv, '=') <= 0 {
+			return nil, errors.New("zkvm: invalid environment variable " + kv)
+		}
+	}
+
+	buf := binary.LittleEndian.AppendUint64(nil, ARGS_MAGIC)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(args)))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(env)))
+
+	for _, s := range append(args[:len(args):len(args)], env...) {
+		buf = append(buf, s...)
+		buf = append(buf, 0)
+	}
+
+	for len(buf)%8 != 0 {
+		buf = append(buf, 0)
+	}
+
+	return append(buf, payload...), nil
+}
+
+// parseArgs parses the argument header at the start of b, storing the offset
+// of each string in offs. It returns the number of arguments and environment
+// variables and the header length, which is zero when b does not start with
+// an argument header.
+//
+// parseArgs runs before the runtime is initialized and must not allocate.
+func parseArgs(b []byte, offs *[MAX_ARGS]int) (argc int, envc int, n int, ok bool) {
+	if len(b) < ARGS_DATA_OFFSET || binary.LittleEndian.Uint64(b) != ARGS_MAGIC {
+		return 0, 0, 0, true
+	}
+
+	argc = int(binary.LittleEndian.Uint32(b[8:]))
+	envc = int(binary.LittleEndian.Uint32(b[12:]))
+
+	if argc == 0 || argc > MAX_ARGS || envc > MAX_ARGS-argc {
+		return 0, 0, 0, false
+	}
+
+	n = ARGS_DATA_OFFSET
+
+	for i := 0; i < argc+envc; i++ {
+		offs[i] = n
+
+		for n < len(b) && b[n] != 0 {
+			n++
+		}
+
+		if n == len(b) {
+			return 0, 0, 0, false
+		}
+
+		n++
+	}
+
+	n = (n + 7) &^ 7
+
+	if n > len(b) {
+		return 0, 0, 0, false
+	}
+
+	return argc, envc, n, true
+}
@@ -0,0 +1,94 @@
+//go:build !(tamago && riscv64)
+
+package zkvm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func cstring(b []byte) string {
+	return string(b[:bytes.IndexByte(b, 0)])
+}
+
+func TestArgs(t *testing.T) {
+	args := []string{"prog", "-test.v", ""}
+	env := []string{"GOGC=50", "FOO="}
+	payload := []byte{1, 2, 3}
+
+	b, err := EncodeArgs(args, env, payload)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var offs [MAX_ARGS]int
+	argc, envc, n, ok := parseArgs(b, &offs)
+
+	if !ok || argc != len(args) || envc != len(env) || n%8 != 0 {
+		t.Fatalf("parseArgs = %d, %d, %d, %v", argc, envc, n, ok)
+	}
+
+	for i, s := range append(args, env...) {
+		if got := cstring(b[offs[i]:]); got != s {
+			t.Errorf("string %d = %q, want %q", i, got, s)
+		}
+	}
+
+	if !bytes.Equal(b[n:], payload) {
+		t.Errorf("payload = %x, want %x", b[n:], payload)
+	}
+}
+
+func TestArgsNoHeader(t *testing.T) {
+	var offs [MAX_ARGS]int
+
+	for _, b := range [][]byte{nil, {1, 2, 3}, make([]byte, 32)} {
+		if argc, envc, n, ok := parseArgs(b, &offs); !ok || argc != 0 || envc != 0 || n != 0 {
+			t.Errorf("parseArgs(%x) = %d, %d, %d, %v", b, argc, envc, n, ok)
+		}
+	}
+}
+
+func TestArgsInvalid(t *testing.T) {
+	var offs [MAX_ARGS]int
+
+	b, err := EncodeArgs([]string{"prog", "arg"}, nil, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := func(argc, envc uint32) []byte {
+		h := binary.LittleEndian.AppendUint64(nil, ARGS_MAGIC)
+		h = binary.LittleEndian.AppendUint32(h, argc)
+		return binary.LittleEndian.AppendUint32(h, envc)
+	}
+
+	for name, b := range map[string][]byte{
+		"truncated": b[:len(b)-3],
+		"padding":   b[:len(b)-1],
+		"argc":      header(0, 0),
+		"count":     header(MAX_ARGS, 1),
+		"strings":   header(2, 0),
+	} {
+		if _, _, _, ok := parseArgs(b, &offs); ok {
+			t.Errorf("parseArgs(%s) succeeded", name)
+		}
+	}
+
+	for _, tc := range []struct {
+		args, env []string
+	}{
+		{nil, nil},
+		{[]string{"prog\x00"}, nil},
+		{[]string{"prog"}, []string{"FOO"}},
+		{[]string{"prog"}, []string{"=bar"}},
+		{[]string{"prog"}, make([]string, MAX_ARGS)},
+	} {
+		if _, err := EncodeArgs(tc.args, tc.env, nil); err == nil {
+			t.Errorf("EncodeArgs(%q, %q) succeeded", tc.args, tc.env)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"errors"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+	_ "unsafe"
+)
+
+// Clock models, the zkVM has no real clock and does not expose its step
+// counter to the guest, therefore time is always synthesized
+// deterministically so that runs are reproducible.
+const (
+	// CLOCK_STEP advances the clock by CLOCK_STEP_NS on each read, giving
+	// a monotonic clock which counts clock reads (default).
+	CLOCK_STEP = iota
+	// CLOCK_FROZEN never advances the clock on reads, time.Now() returns
+	// the epoch until all goroutines sleep (see timejump).
+	CLOCK_FROZEN
+)
+
+// CLOCK_STEP_NS is the clock increment, in nanoseconds, of each read under
+// CLOCK_STEP.
+const CLOCK_STEP_NS = 1000
+
+// ErrClock is returned when an invalid clock model is selected.
+var ErrClock = errors.New("zkvm: invalid clock model")
+
+// ErrEpoch is returned when the clock is set to a time earlier than the
+// current one, or beyond the range of the monotonic clock.
+var ErrEpoch = errors.New("zkvm: invalid clock epoch")
+
+var clock struct {
+	model int
+	// current time in nanoseconds since the Unix epoch
+	now int64
+}
+
+// The runtime derives the wall clock from its monotonic clock, nanotime1
+// therefore counts from the configured epoch.
+//
+//go:linkname nanotime1 runtime.nanotime1
+func nanotime1() int64 {
+	if clock.model == CLOCK_STEP {
+		clock.now += CLOCK_STEP_NS
+	}
+
+	return clock.now
+}
+
+// timejump implements runtime.Timejump, it is invoked when all goroutines
+// sleep to move the clock to the earliest timer expiration, so that sleeping
+// costs no proving cycles, under both clock models.
+//
+//go:nosplit
+func timejump(until int64) {
+	if until > clock.now {
+		clock.now = until
+	}
+}
+
+// SetClock selects the clock model and sets the current time to epoch (e.g.
+// a block timestamp taken from the input).
+//
+// The runtime timers are driven by the same clock, which therefore can only
+// move forward: an epoch earlier than the current time returns ErrEpoch.
+func SetClock(model int, epoch time.Time) error {
+	if model != CLOCK_STEP && model != CLOCK_FROZEN {
+		return ErrClock
+	}
+
+	if epoch.Before(time.Unix(0, clock.now)) || epoch.After(time.Unix(0, math.MaxInt64)) {
+		return ErrEpoch
+	}
+
+	clock.model = model
+	clock.now = epoch.UnixNano()
+
+	return nil
+}
+
+// The clock can also be configured through the environment, either at link
+// time (-ldflags -env) or from the input argument header:
+//
+//	ZKVM_CLOCK  step (default) or frozen
+//	ZKVM_EPOCH  initial time in seconds since the Unix epoch (default 0)
+func init() {
+	model := CLOCK_STEP
+	epoch := int64(0)
+
+	switch m := os.Getenv("ZKVM_CLOCK"); m {
+	case "", "step":
+	case "frozen":
+		model = CLOCK_FROZEN
+	default:
+		panic("zkvm: invalid ZKVM_CLOCK " + m)
+	}
+
+	if e := os.Getenv("ZKVM_EPOCH"); e != "" {
+		var err error
+
+		// the epoch must be representable, in nanoseconds, by the
+		// monotonic clock
+		if epoch, err = strconv.ParseInt(e, 10, 64); err != nil || epoch < 0 || epoch > (math.MaxInt64-clock.now)/int64(time.Second) {
+			panic("zkvm: invalid ZKVM_EPOCH " + e)
+		}
+	}
+
+	// the clock has already been read during runtime initialization, the
+	// epoch is added so that the monotonic clock never goes backwards
+	clock.model = model
+	clock.now += epoch * int64(time.Second)
+
+	runtime.Timejump = timejump
+}
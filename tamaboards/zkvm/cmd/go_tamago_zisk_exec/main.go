@@ -0,0 +1,214 @@
+//go:build !tamago
+
+// This program can be used with go test -exec, or installed in PATH as
+// go_tamago_riscv64_exec, to run tamago/riscv64 test binaries linked with the
+// tamaboards/zkvm board, or the ZisK testing board of the testing package,
+// under the ZisK emulator (ziskemu).
+package main
+
+import (
+	"bytes"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"tamagotest/tamaboards/zkvm"
+)
+
+// env lists the variables passed from the wrapper environment to the program,
+// along with any ZKVM_ board setting (e.g. ZKVM_SEED).
+var env = []string{"GOGC", "GODEBUG", "GOMEMLIMIT", "GOTRACEBACK"}
+
+// buildFlags are the flags required to build for the board, packages not
+// importing it are built with the zkvm_testing tag instead.
+var buildFlags = fmt.Sprintf("-tags zkvm (or zkvm_testing) -ldflags %q", zkvm.LinkFlags())
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go_tamago_zisk_exec: ")
+	if len(os.Args) < 2 {
+		log.Fatal("usage: go_tamago_zisk_exec a.out [args...]")
+	}
+	exitCode, err := runMain()
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(exitCode)
+}
+
+func runMain() (int, error) {
+	bin := os.Args[1]
+	if err := checkELF(bin); err != nil {
+		return 0, fmt.Errorf("%v, build with %s", err, buildFlags)
+	}
+
+	ziskemu := os.Getenv("ZISKEMU")
+	if ziskemu == "" {
+		var err error
+		if ziskemu, err = exec.LookPath("ziskemu"); err != nil {
+			return 0, errors.New("ziskemu not found in PATH, set ZISKEMU to its location")
+		}
+	}
+
+	input, err := zkvm.EncodeArgs(append([]string{filepath.Base(bin)}, os.Args[2:]...), environ(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(input) > zkvm.MAX_INPUT-zkvm.INPUT_DATA_OFFSET {
+		return 0, fmt.Errorf("arguments exceed the %d bytes input window", zkvm.MAX_INPUT-zkvm.INPUT_DATA_OFFSET)
+	}
+	f, err := os.CreateTemp("", "go_tamago_zisk_exec_input_")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(input); err != nil {
+		f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+
+	args := []string{"-e", bin, "-i", f.Name()}
+	if flags := os.Getenv("ZISKEMU_FLAGS"); flags != "" {
+		args = append(args, strings.Fields(flags)...)
+	}
+	filter := newExitCodeFilter(os.Stdout)
+	cmd := exec.Command(ziskemu, args...)
+	cmd.Stdout = filter
+	// Wrap stderr so that a hanging emulator does not hold it open once go
+	// test kills this wrapper (see go_android_exec).
+	cmd.Stderr = struct{ io.Writer }{os.Stderr}
+	err = cmd.Run()
+
+	// Flush any further output and get the exit code.
+	exitCode, err2 := filter.Finish()
+	if err != nil {
+		return 0, fmt.Errorf("%s %s: %v", ziskemu, strings.Join(args, " "), err)
+	}
+	if err2 != nil {
+		return 0, fmt.Errorf("%v, was %s built with %s?", err2, bin, buildFlags)
+	}
+	return exitCode, nil
+}
+
+// environ returns the variables of the wrapper environment passed to the
+// program.
+func environ() (kv []string) {
+	for _, s := range os.Environ() {
+		k, _, _ := strings.Cut(s, "=")
+		for _, e := range env {
+			if k == e {
+				kv = append(kv, s)
+			}
+		}
+		if strings.HasPrefix(k, "ZKVM_") {
+			kv = append(kv, s)
+		}
+	}
+	return
+}
+
+// checkELF verifies that bin is a riscv64 executable whose loaded sections all
+// lie in the ZisK ROM or RAM, as the emulator fails on any access outside of
+// them.
+func checkELF(bin string) error {
+	f, err := elf.Open(bin)
+	if err != nil {
+		return fmt.Errorf("%s: %v", bin, err)
+	}
+	defer f.Close()
+
+	if f.Class != elf.ELFCLASS64 || f.Machine != elf.EM_RISCV {
+		return fmt.Errorf("%s is not a riscv64 executable", bin)
+	}
+	for _, s := range f.Sections {
+		// ziskemu only loads these
+		if s.Type != elf.SHT_PROGBITS && s.Type != elf.SHT_NOBITS {
+			continue
+		}
+		if s.Flags&elf.SHF_ALLOC == 0 || s.Size == 0 {
+			continue
+		}
+		inROM := s.Addr >= zkvm.ROM_ADDR && s.Addr+s.Size <= zkvm.ROM_ADDR+zkvm.ROM_SIZE
+		inRAM := s.Addr >= zkvm.RAM_ADDR && s.Addr+s.Size <= zkvm.RAM_END
+		if !inRAM && (s.Flags&elf.SHF_WRITE != 0 || !inROM) {
+			return fmt.Errorf("%s: section %s at %#x is outside of the ZisK memory map", bin, s.Name, s.Addr)
+		}
+	}
+	return nil
+}
+
+// exitCodeFilter is a writer that passes output through until the exit
+// status line printed by the board (see zkvm.EXIT_STATUS), which it parses.
+// Anything after it, such as the public output logged by ziskemu, is
+// discarded.
+type exitCodeFilter struct {
+	w      io.Writer // Pass through to w
+	exitRe *regexp.Regexp
+	buf    bytes.Buffer
+	done   bool
+	code   int
+}
+
+var exitCodeRe = regexp.MustCompile(regexp.QuoteMeta(zkvm.EXIT_STATUS) + "(-?[0-9]+)\n")
+
+func newExitCodeFilter(w io.Writer) *exitCodeFilter {
+	// Build a regexp that matches any prefix of the exit status line at
+	// the end of the input, which may be completed by a later write.
+	var exitReStr strings.Builder
+	for i := 1; i <= len(zkvm.EXIT_STATUS); i++ {
+		fmt.Fprintf(&exitReStr, "%s$|", regexp.QuoteMeta(zkvm.EXIT_STATUS[:i]))
+	}
+	fmt.Fprintf(&exitReStr, "%s-?[0-9]*$", regexp.QuoteMeta(zkvm.EXIT_STATUS))
+	return &exitCodeFilter{w: w, exitRe: regexp.MustCompile(exitReStr.String())}
+}
+
+func (f *exitCodeFilter) Write(data []byte) (int, error) {
+	n := len(data)
+	if f.done {
+		return n, nil
+	}
+	f.buf.Write(data)
+	b := f.buf.Bytes()
+	if match := exitCodeRe.FindSubmatchIndex(b); match != nil {
+		code, err := strconv.ParseInt(string(b[match[2]:match[3]]), 10, 32)
+		if err == nil {
+			// Flush up to the exit status line and discard the rest.
+			f.done = true
+			f.code = int(code)
+			_, err := f.w.Write(b[:match[0]])
+			f.buf.Reset()
+			return n, err
+		}
+	}
+	// Flush up to the beginning of a potential exit status line.
+	end := len(b)
+	if match := f.exitRe.FindIndex(b); match != nil {
+		end = match[0]
+	}
+	_, err := f.w.Write(b[:end])
+	f.buf.Next(end)
+	return n, err
+}
+
+func (f *exitCodeFilter) Finish() (int, error) {
+	defer f.buf.Reset()
+	if !f.done {
+		b := f.buf.Bytes()
+		if _, err := f.w.Write(b); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("no exit status reported")
+	}
+	return f.code, nil
+}
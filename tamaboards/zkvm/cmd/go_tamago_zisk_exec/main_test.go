@@ -0,0 +1,93 @@
+//go:build !tamago
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"tamagotest/tamaboards/zkvm"
+)
+
+const exitStr = zkvm.EXIT_STATUS
+
+func TestExitCodeFilter(t *testing.T) {
+	// Write text to the filter one character at a time.
+	var out strings.Builder
+	f := newExitCodeFilter(&out)
+	// Embed a partial exit status line in the middle to check that we
+	// don't get caught on it.
+	pre := "=== RUN TestOK\n" + exitStr + "abc\nPASS"
+	text := pre + exitStr + "1\n00000000\n"
+	for i := 0; i < len(text); i++ {
+		_, err := f.Write([]byte{text[i]})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The "pre" output should all have been flushed already, and the
+	// ziskemu output after the exit status discarded.
+	if want, got := pre, out.String(); want != got {
+		t.Errorf("filter should have flushed %q, but flushed %q", want, got)
+	}
+
+	code, err := f.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := pre, out.String(); want != got {
+		t.Errorf("want output %q, got %q", want, got)
+	}
+	if want := 1; want != code {
+		t.Errorf("want exit code %d, got %d", want, code)
+	}
+}
+
+func TestExitCodeNegative(t *testing.T) {
+	var out strings.Builder
+	f := newExitCodeFilter(&out)
+	f.Write([]byte("FAIL" + exitStr + "-"))
+	f.Write([]byte("1\n"))
+	code, err := f.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "FAIL", out.String(); want != got {
+		t.Errorf("want output %q, got %q", want, got)
+	}
+	if want := -1; want != code {
+		t.Errorf("want exit code %d, got %d", want, code)
+	}
+}
+
+func TestExitCodeMissing(t *testing.T) {
+	for _, text := range []string{"abc", "\nzisk_exit", exitStr, exitStr + "12", exitStr + "99999999999\n"} {
+		var out strings.Builder
+		f := newExitCodeFilter(&out)
+		f.Write([]byte(text))
+		if _, err := f.Finish(); err == nil || err.Error() != "no exit status reported" {
+			t.Errorf("%q: want no exit status error, got %v", text, err)
+		}
+		// All output should be flushed.
+		if got := out.String(); text != got {
+			t.Errorf("want full output %q, got %q", text, got)
+		}
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	t.Setenv("GOGC", "50")
+	t.Setenv("ZKVM_SEED", "00")
+	t.Setenv("GOPATH_UNRELATED", "x")
+
+	got := " " + strings.Join(environ(), " ") + " "
+	for _, kv := range []string{"GOGC=50", "ZKVM_SEED=00"} {
+		if !strings.Contains(got, " "+kv+" ") {
+			t.Errorf("environ() = %q, missing %s", got, kv)
+		}
+	}
+	if strings.Contains(got, "GOPATH_UNRELATED") {
+		t.Errorf("environ() = %q, unexpected GOPATH_UNRELATED", got)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !tamago
+
+// This program prints the linker flags placing a program in the
+// tamaboards/zkvm board memory map (see zkvm.LinkFlags), for the Makefile.
+package main
+
+import (
+	"fmt"
+
+	"tamagotest/tamaboards/zkvm"
+)
+
+func main() {
+	fmt.Println(zkvm.LinkFlags())
+}
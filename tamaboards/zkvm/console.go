@@ -0,0 +1,18 @@
+//go:build tamago && riscv64 && !zkvm_silent
+
+package zkvm
+
+import (
+	"unsafe"
+)
+
+// printk implementation for zkVM, runtime console output (print, println,
+// panics and writes to os.Stdout/os.Stderr) is sent to the ZisK UART which
+// echoes it on the emulator standard output.
+//
+// Build with the zkvm_silent tag to discard console output.
+//
+//go:linkname printk runtime.printk
+func printk(c byte) {
+	*(*byte)(unsafe.Pointer(uintptr(UART_ADDR))) = c
+}
@@ -8,11 +8,6 @@ TEXT cpuinit(SB),NOSPLIT|NOFRAME,$0
 	// argc/argv arguments
 	MOV	$0, A0
 	MOV	$0, A1
-	// Jump to tamago runtime for RISC-V
-	JMP	runtime·rt0_riscv64_tamago(SB)
-
-// hwinit0 is called by the runtime during initialization
-TEXT runtime·hwinit0(SB),NOSPLIT|NOFRAME,$0
-	// Hardware initialization before runtime setup
-	// For zkVM, nothing special needed here
-	RET
+	// ZisK does not initialize SP, _rt0_tamago_start sets it to the
+	// top of the stack (RAM_START + RAM_SIZE - STACK_OFFSET)
+	JMP	_rt0_tamago_start(SB)
@@ -0,0 +1,83 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"os"
+	"runtime"
+	"unsafe"
+)
+
+// writeStatus prints the exit status line on the UART, it must not allocate.
+func writeStatus(code int32) {
+	uart := (*byte)(unsafe.Pointer(uintptr(UART_ADDR)))
+
+	for i := 0; i < len(EXIT_STATUS); i++ {
+		*uart = EXIT_STATUS[i]
+	}
+
+	n := uint32(code)
+
+	if code < 0 {
+		*uart = '-'
+		n = -n
+	}
+
+	var buf [10]byte
+	i := len(buf)
+
+	for ; ; n /= 10 {
+		i--
+		buf[i] = byte('0' + n%10)
+
+		if n < 10 {
+			break
+		}
+	}
+
+	for _, c := range buf[i:] {
+		*uart = c
+	}
+
+	*uart = '\n'
+}
+
+// exit is defined in shutdown.s
+func exit(code int32)
+
+// shutdown implements runtime.Exit, which is invoked by os.Exit, on return
+// from main and on fatal runtime errors.
+//
+// The Go runtime exits with code 2 on any fatal condition, in which case the
+// fatal error report is stored at FATAL_ADDR and the status refined.
+func shutdown(code int32) {
+	if r := runtime.LastFatalError(); code == 2 && r != nil {
+		writeFatal(r)
+
+		switch {
+		case runtime.OutOfMemory():
+			code = EXIT_OOM
+		case runtime.Deadlocked():
+			code = EXIT_DEADLOCK
+		case r.Throw:
+			code = EXIT_FATAL
+		default:
+			code = EXIT_PANIC
+		}
+	}
+
+	writeStatus(code)
+	exit(code)
+}
+
+// Shutdown halts the program with EXIT_SUCCESS.
+func Shutdown() {
+	shutdown(EXIT_SUCCESS)
+}
+
+// The runtime resource summary (see runtime.ExitSummary) is printed on the
+// console before exit when the environment, either at link time or from the
+// input argument header, sets ZKVM_SUMMARY=1.
+func init() {
+	runtime.ExitSummary = os.Getenv("ZKVM_SUMMARY") == "1"
+}
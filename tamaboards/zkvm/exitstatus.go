@@ -0,0 +1,24 @@
+package zkvm
+
+// Exit status codes, reported on the UART status line (see EXIT_STATUS) and
+// passed in A0 to the exit ecall.
+const (
+	// EXIT_SUCCESS is reported on a normal return from main.
+	EXIT_SUCCESS = 0
+	// EXIT_PANIC is reported on unrecovered panics.
+	EXIT_PANIC = 2
+	// EXIT_DEADLOCK is reported when all goroutines are asleep, as nothing
+	// can wake them up.
+	EXIT_DEADLOCK = 3
+	// EXIT_FATAL is reported on fatal runtime errors (e.g. throw).
+	EXIT_FATAL = 134
+	// EXIT_OOM is reported when the runtime aborts because the heap
+	// exhausted the board RAM.
+	EXIT_OOM = 137
+)
+
+// EXIT_STATUS precedes the decimal exit status on the line printed on the
+// UART right before the exit ecall. ZisK ignores A0 on exit, this line is
+// therefore the only way for the host to read the status, it is printed also
+// under zkvm_silent.
+const EXIT_STATUS = "\nzisk_exitcode="
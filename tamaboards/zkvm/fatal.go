@@ -0,0 +1,137 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Fatal error report record, written at FATAL_ADDR when the runtime
+// terminates on an unrecovered panic or fatal error (all fields are
+// little-endian, variable length fields are padded to 8 bytes):
+//
+//	0x00 u32 FATAL_MAGIC
+//	0x04 u32 kind (FATAL_PANIC, FATAL_THROW)
+//	0x08 u64 PC of the faulting frame
+//	0x10 u64 goroutine id
+//	0x18 u32 message length
+//	0x1c u32 frame count
+//	0x20 message
+//
+// followed by each frame:
+//
+//	0x00 u64 PC
+//	0x08 u32 line
+//	0x0c u16 function name length
+//	0x0e u16 file name length
+//	0x10 function name, file name
+//
+// Strings are truncated, and trailing frames dropped, to fit FATAL_SIZE.
+const (
+	FATAL_MAGIC = 0x4c544146 // "FATL"
+
+	FATAL_PANIC = 1
+	FATAL_THROW = 2
+)
+
+type fatalWriter struct {
+	off uintptr
+}
+
+func (w *fatalWriter) free() int {
+	return int(FATAL_SIZE - w.off)
+}
+
+func (w *fatalWriter) align() {
+	w.off = (w.off + 7) &^ 7
+}
+
+func (w *fatalWriter) u16(v uint16) {
+	*(*uint16)(unsafe.Pointer(FATAL_ADDR + w.off)) = v
+	w.off += 2
+}
+
+func (w *fatalWriter) u32(v uint32) {
+	*(*uint32)(unsafe.Pointer(FATAL_ADDR + w.off)) = v
+	w.off += 4
+}
+
+func (w *fatalWriter) u64(v uint64) {
+	*(*uint64)(unsafe.Pointer(FATAL_ADDR + w.off)) = v
+	w.off += 8
+}
+
+func (w *fatalWriter) str(s string) {
+	for i := 0; i < len(s); i++ {
+		*(*byte)(unsafe.Pointer(FATAL_ADDR + w.off)) = s[i]
+		w.off++
+	}
+}
+
+func truncate(s string, n int) string {
+	if n < 0 {
+		return ""
+	}
+
+	if len(s) > n {
+		return s[:n]
+	}
+
+	return s
+}
+
+// writeFatal stores the runtime fatal error report at FATAL_ADDR.
+func writeFatal(r *runtime.FatalError) {
+	w := &fatalWriter{}
+
+	w.u32(FATAL_MAGIC)
+
+	if r.Throw {
+		w.u32(FATAL_THROW)
+	} else {
+		w.u32(FATAL_PANIC)
+	}
+
+	w.u64(uint64(r.PC))
+	w.u64(r.Goid)
+
+	msg := truncate(r.Message(), w.free()-8)
+	w.u32(uint32(len(msg)))
+
+	// frame count, patched below
+	count := w.off
+	w.u32(0)
+
+	w.str(msg)
+	w.align()
+
+	n := uint32(0)
+
+	for i := 0; i < r.Frames(); i++ {
+		pc, function, file, line := r.Frame(i)
+
+		if w.free() < 16 {
+			break
+		}
+
+		function = truncate(function, w.free()-16)
+		file = truncate(file, w.free()-16-len(function))
+
+		w.u64(uint64(pc))
+		w.u32(uint32(line))
+		w.u16(uint16(len(function)))
+		w.u16(uint16(len(file)))
+		w.str(function)
+		w.str(file)
+		w.align()
+
+		n++
+
+		if w.off >= FATAL_SIZE {
+			break
+		}
+	}
+
+	*(*uint32)(unsafe.Pointer(FATAL_ADDR + count)) = n
+}
@@ -0,0 +1,256 @@
+// Package fcall provides Go bindings for the ZisK free-input calls (fcall),
+// which request non-deterministic hints from the host.
+//
+// On the zkVM (tamago && riscv64) each hint is requested by passing its
+// parameters through the fcall parameter CSRs (0x8F0-0x8FF), invoking the
+// function through the fcall CSRs (0x8C0-0x8DF) and reading back its results
+// from the fcall get CSR (0xFFE), as ziskos does. On any other build the hints
+// are computed in pure Go, so that the same code runs in unit tests on the
+// host.
+//
+// Hints are not constrained by the zkVM. The *Hint functions return the raw
+// hint, which the caller must verify, while the remaining functions verify it
+// with the precompiles and return ErrHint when the host supplied an incorrect
+// value.
+//
+// 256-bit values are represented as four little-endian uint64 limbs.
+package fcall
+
+import (
+	"errors"
+
+	"tamagotest/tamaboards/zkvm/precompile"
+)
+
+// Free-input call function identifiers, see ziskos fcalls.
+const (
+	FCALL_SECP256K1_FP_INV_ID  = 1
+	FCALL_SECP256K1_FN_INV_ID  = 2
+	FCALL_SECP256K1_FP_SQRT_ID = 3
+	FCALL_MSB_POS_256_ID       = 4
+	FCALL_BN254_FP_INV_ID      = 6
+	FCALL_BN254_FP2_INV_ID     = 7
+)
+
+var (
+	// ErrHint is returned when a hint supplied by the host fails
+	// verification.
+	ErrHint = errors.New("fcall: invalid hint")
+	// ErrZero is returned when an operand must not be zero.
+	ErrZero = errors.New("fcall: zero operand")
+)
+
+var (
+	// secp256k1 base field modulus
+	secp256k1P = [4]uint64{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	// secp256k1 group order
+	secp256k1N = [4]uint64{0xbfd25e8cd0364141, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff}
+	// (secp256k1P - 1) / 2
+	secp256k1PHalf = [4]uint64{0xffffffff7ffffe17, 0xffffffffffffffff, 0xffffffffffffffff, 0x7fffffffffffffff}
+	// secp256k1P - 1
+	secp256k1PMinusOne = [4]uint64{0xfffffffefffffc2e, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	// BN254 base field modulus
+	bn254P = [4]uint64{0x3c208c16d87cfd47, 0x97816a916871ca8d, 0xb85045b68181585d, 0x30644e72e131a029}
+)
+
+var (
+	zero = [4]uint64{}
+	one  = [4]uint64{1}
+)
+
+// mulAddMod returns (a * b + c) mod m.
+func mulAddMod(a, b, c, m *[4]uint64) (d [4]uint64) {
+	precompile.Arith256Mod(a, b, c, m, &d)
+	return
+}
+
+// reduce returns x mod m.
+func reduce(x, m *[4]uint64) [4]uint64 {
+	return mulAddMod(x, &one, &zero, m)
+}
+
+// expMod returns x^e mod m.
+func expMod(x, e, m *[4]uint64) [4]uint64 {
+	r := one
+
+	for i := 255; i >= 0; i-- {
+		r = mulAddMod(&r, &r, &zero, m)
+
+		if e[i/64]>>(i%64)&1 == 1 {
+			r = mulAddMod(&r, x, &zero, m)
+		}
+	}
+
+	return r
+}
+
+// inv verifies and returns the hinted inverse of x modulo m, the inverse of
+// zero is zero (as zisklib).
+func inv(x, m *[4]uint64, hint func(x, r *[4]uint64)) (r [4]uint64, err error) {
+	if *x = reduce(x, m); *x == zero {
+		return
+	}
+
+	hint(x, &r)
+
+	// r + k*m would also satisfy the product
+	if reduce(&r, m) != r || mulAddMod(x, &r, &zero, m) != one {
+		return zero, ErrHint
+	}
+
+	return
+}
+
+// Secp256k1FpInvHint returns the unverified hint for the inverse of x in the
+// secp256k1 base field.
+func Secp256k1FpInvHint(x *[4]uint64) (r [4]uint64) {
+	secp256k1FpInv(x, &r)
+	return
+}
+
+// Secp256k1FpInv returns the inverse of x in the secp256k1 base field, the
+// inverse of zero is zero.
+func Secp256k1FpInv(x *[4]uint64) ([4]uint64, error) {
+	v := *x
+	return inv(&v, &secp256k1P, secp256k1FpInv)
+}
+
+// Secp256k1FnInvHint returns the unverified hint for the inverse of x in the
+// secp256k1 scalar field.
+func Secp256k1FnInvHint(x *[4]uint64) (r [4]uint64) {
+	secp256k1FnInv(x, &r)
+	return
+}
+
+// Secp256k1FnInv returns the inverse of x in the secp256k1 scalar field, the
+// inverse of zero is zero.
+func Secp256k1FnInv(x *[4]uint64) ([4]uint64, error) {
+	v := *x
+	return inv(&v, &secp256k1N, secp256k1FnInv)
+}
+
+// Secp256k1FpSqrtHint returns the unverified hint for the square root of x
+// in the secp256k1 base field with the given parity (0 even, 1 odd), ok is
+// false when the host claims that x is not a quadratic residue.
+func Secp256k1FpSqrtHint(x *[4]uint64, parity uint64) (r [4]uint64, ok bool) {
+	ok = secp256k1FpSqrt(x, parity, &r)
+	return
+}
+
+// Secp256k1FpSqrt returns the square root of x in the secp256k1 base field
+// with the given parity (0 even, 1 odd), ok is false when x is not a
+// quadratic residue.
+//
+// A missing root is verified with Euler's criterion, which costs a modular
+// exponentiation.
+func Secp256k1FpSqrt(x *[4]uint64, parity uint64) (r [4]uint64, ok bool, err error) {
+	v := reduce(x, &secp256k1P)
+
+	parity &= 1
+
+	if r, ok = Secp256k1FpSqrtHint(&v, parity); ok {
+		if reduce(&r, &secp256k1P) != r || mulAddMod(&r, &r, &zero, &secp256k1P) != v {
+			return zero, false, ErrHint
+		}
+
+		// zero is its own (even) root
+		if r[0]&1 != parity && r != zero {
+			return zero, false, ErrHint
+		}
+
+		return
+	}
+
+	// x is a non-residue if and only if x^((p-1)/2) = -1
+	if expMod(&v, &secp256k1PHalf, &secp256k1P) != secp256k1PMinusOne {
+		return zero, false, ErrHint
+	}
+
+	return
+}
+
+// MsbPos256Hint returns the unverified hint for the position of the most
+// significant bit set in either x or y, as the index of its limb and its bit
+// within the limb.
+func MsbPos256Hint(x, y *[4]uint64) (limb int, bit int) {
+	l, b := msbPos256(x, y)
+	return int(l), int(b)
+}
+
+// MsbPos256 returns the position of the most significant bit set in either x
+// or y, as the index of its limb and its bit within the limb. ErrZero is
+// returned when x and y are both zero.
+func MsbPos256(x, y *[4]uint64) (limb int, bit int, err error) {
+	if *x == zero && *y == zero {
+		return 0, 0, ErrZero
+	}
+
+	limb, bit = MsbPos256Hint(x, y)
+
+	if limb < 0 || limb > 3 || bit < 0 || bit > 63 {
+		return 0, 0, ErrHint
+	}
+
+	for i := limb + 1; i < 4; i++ {
+		if x[i] != 0 || y[i] != 0 {
+			return 0, 0, ErrHint
+		}
+	}
+
+	if (x[limb]|y[limb])>>bit != 1 {
+		return 0, 0, ErrHint
+	}
+
+	return
+}
+
+// Bn254FpInvHint returns the unverified hint for the inverse of x in the
+// BN254 base field.
+func Bn254FpInvHint(x *[4]uint64) (r [4]uint64) {
+	bn254FpInv(x, &r)
+	return
+}
+
+// Bn254FpInv returns the inverse of x in the BN254 base field, the inverse of
+// zero is zero.
+func Bn254FpInv(x *[4]uint64) ([4]uint64, error) {
+	v := *x
+	return inv(&v, &bn254P, bn254FpInv)
+}
+
+// Bn254Fp2InvHint returns the unverified hint for the inverse of x in the
+// BN254 base field quadratic extension.
+func Bn254Fp2InvHint(x *precompile.Complex256) (r precompile.Complex256) {
+	bn254Fp2Inv(x, &r)
+	return
+}
+
+// Bn254Fp2Inv returns the inverse of x in the BN254 base field quadratic
+// extension, the inverse of zero is zero. The coordinates of x must be within
+// the BN254 base field.
+func Bn254Fp2Inv(x *precompile.Complex256) (r precompile.Complex256, err error) {
+	return fp2Inv(x, bn254Fp2Inv)
+}
+
+// fp2Inv verifies and returns the hinted inverse of x in the BN254 base field
+// quadratic extension.
+func fp2Inv(x *precompile.Complex256, hint func(x, r *precompile.Complex256)) (r precompile.Complex256, err error) {
+	if *x == (precompile.Complex256{}) {
+		return
+	}
+
+	hint(x, &r)
+
+	if reduce(&r.X, &bn254P) != r.X || reduce(&r.Y, &bn254P) != r.Y {
+		return precompile.Complex256{}, ErrHint
+	}
+
+	f := *x
+	precompile.Bn254ComplexMul(&f, &r)
+
+	if f != (precompile.Complex256{X: one}) {
+		return precompile.Complex256{}, ErrHint
+	}
+
+	return
+}
@@ -0,0 +1,86 @@
+//go:build !(tamago && riscv64)
+
+package fcall
+
+import (
+	"math/big"
+	"math/bits"
+
+	"tamagotest/tamaboards/zkvm/internal/limbs"
+	"tamagotest/tamaboards/zkvm/precompile"
+)
+
+// Pure-Go implementations of the ZisK free-input calls, for host builds.
+
+// modInverse sets r to the inverse of x modulo m, or to zero if x is not
+// invertible.
+func modInverse(x, r, m *[4]uint64) {
+	b := limbs.ToBig(x)
+
+	if b.ModInverse(b, limbs.ToBig(m)) == nil {
+		*r = [4]uint64{}
+		return
+	}
+
+	limbs.FromBig(b, r)
+}
+
+func secp256k1FpInv(x, r *[4]uint64) {
+	modInverse(x, r, &secp256k1P)
+}
+
+func secp256k1FnInv(x, r *[4]uint64) {
+	modInverse(x, r, &secp256k1N)
+}
+
+func secp256k1FpSqrt(x *[4]uint64, parity uint64, r *[4]uint64) (ok bool) {
+	p := limbs.ToBig(&secp256k1P)
+	s := new(big.Int).Mod(limbs.ToBig(x), p)
+
+	if s.ModSqrt(s, p) == nil {
+		*r = [4]uint64{}
+		return false
+	}
+
+	if s.Sign() != 0 && s.Bit(0) != uint(parity) {
+		s.Sub(p, s)
+	}
+
+	limbs.FromBig(s, r)
+
+	return true
+}
+
+func msbPos256(x, y *[4]uint64) (limb uint64, bit uint64) {
+	for i := 3; i >= 0; i-- {
+		if w := max(x[i], y[i]); w != 0 {
+			return uint64(i), uint64(bits.Len64(w) - 1)
+		}
+	}
+
+	return
+}
+
+func bn254FpInv(x, r *[4]uint64) {
+	modInverse(x, r, &bn254P)
+}
+
+func bn254Fp2Inv(x, r *precompile.Complex256) {
+	p := limbs.ToBig(&bn254P)
+	a, b := limbs.ToBig(&x.X), limbs.ToBig(&x.Y)
+
+	// 1 / (a + bi) = (a - bi) / (a² + b²)
+	d := new(big.Int).Mul(a, a)
+	d.Add(d, new(big.Int).Mul(b, b))
+
+	if d.ModInverse(d.Mod(d, p), p) == nil {
+		*r = precompile.Complex256{}
+		return
+	}
+
+	a.Mul(a, d)
+	b.Mul(b.Neg(b), d)
+
+	limbs.FromBig(a.Mod(a, p), &r.X)
+	limbs.FromBig(b.Mod(b, p), &r.Y)
+}
@@ -0,0 +1,96 @@
+//go:build tamago && riscv64
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// Each free-input call passes its parameters with `csrs <0x8F0+n>, a0`, where
+// n encodes the number of 64-bit words pointed by A0 (0 for a single word
+// passed by value, 2 for 4 words, 3 for 8 words), invokes the function with
+// `csrwi <0x8C0+id/32>, id%32` and reads each result word with
+// `csrr t0, 0xFFE`.
+
+#define FCALL_PARAM_VALUE	$0x8f0
+#define FCALL_PARAM_4		$0x8f2
+#define FCALL_PARAM_8		$0x8f3
+#define FCALL			$0x8c0
+#define FCALL_GET		$0xffe
+
+// GET stores the next result word at off(A1).
+#define GET(off) \
+	CSRRS	ZERO, FCALL_GET, T0; \
+	MOV	T0, off(A1)
+
+#define GET4 \
+	GET(0); \
+	GET(8); \
+	GET(16); \
+	GET(24)
+
+// func secp256k1FpInv(x, r *[4]uint64)
+TEXT ·secp256k1FpInv(SB),NOSPLIT,$0-16
+	MOV	x+0(FP), A0
+	MOV	r+8(FP), A1
+	CSRRS	A0, FCALL_PARAM_4, ZERO
+	CSRRWI	$const_FCALL_SECP256K1_FP_INV_ID, FCALL, ZERO
+	GET4
+	RET
+
+// func secp256k1FnInv(x, r *[4]uint64)
+TEXT ·secp256k1FnInv(SB),NOSPLIT,$0-16
+	MOV	x+0(FP), A0
+	MOV	r+8(FP), A1
+	CSRRS	A0, FCALL_PARAM_4, ZERO
+	CSRRWI	$const_FCALL_SECP256K1_FN_INV_ID, FCALL, ZERO
+	GET4
+	RET
+
+// func secp256k1FpSqrt(x *[4]uint64, parity uint64, r *[4]uint64) (ok bool)
+TEXT ·secp256k1FpSqrt(SB),NOSPLIT,$0-25
+	MOV	x+0(FP), A0
+	MOV	parity+8(FP), A2
+	MOV	r+16(FP), A1
+	CSRRS	A0, FCALL_PARAM_4, ZERO
+	CSRRS	A2, FCALL_PARAM_VALUE, ZERO
+	CSRRWI	$const_FCALL_SECP256K1_FP_SQRT_ID, FCALL, ZERO
+	CSRRS	ZERO, FCALL_GET, T0
+	SNEZ	T0, T0
+	MOVB	T0, ok+24(FP)
+	BEQZ	T0, done
+	GET4
+done:
+	RET
+
+// func msbPos256(x, y *[4]uint64) (limb uint64, bit uint64)
+TEXT ·msbPos256(SB),NOSPLIT,$0-32
+	MOV	x+0(FP), A0
+	MOV	y+8(FP), A1
+	CSRRS	A0, FCALL_PARAM_4, ZERO
+	CSRRS	A1, FCALL_PARAM_4, ZERO
+	CSRRWI	$const_FCALL_MSB_POS_256_ID, FCALL, ZERO
+	CSRRS	ZERO, FCALL_GET, T0
+	MOV	T0, limb+16(FP)
+	CSRRS	ZERO, FCALL_GET, T0
+	MOV	T0, bit+24(FP)
+	RET
+
+// func bn254FpInv(x, r *[4]uint64)
+TEXT ·bn254FpInv(SB),NOSPLIT,$0-16
+	MOV	x+0(FP), A0
+	MOV	r+8(FP), A1
+	CSRRS	A0, FCALL_PARAM_4, ZERO
+	CSRRWI	$const_FCALL_BN254_FP_INV_ID, FCALL, ZERO
+	GET4
+	RET
+
+// func bn254Fp2Inv(x, r *precompile.Complex256)
+TEXT ·bn254Fp2Inv(SB),NOSPLIT,$0-16
+	MOV	x+0(FP), A0
+	MOV	r+8(FP), A1
+	CSRRS	A0, FCALL_PARAM_8, ZERO
+	CSRRWI	$const_FCALL_BN254_FP2_INV_ID, FCALL, ZERO
+	GET4
+	GET(32)
+	GET(40)
+	GET(48)
+	GET(56)
+	RET
@@ -0,0 +1,200 @@
+//go:build !(tamago && riscv64)
+
+package fcall
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"tamagotest/tamaboards/zkvm/internal/limbs"
+	"tamagotest/tamaboards/zkvm/precompile"
+)
+
+func randLimbs(t *testing.T, m *[4]uint64) (v [4]uint64) {
+	b, err := rand.Int(rand.Reader, limbs.ToBig(m))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limbs.FromBig(b, &v)
+
+	return
+}
+
+func testInv(t *testing.T, name string, m *[4]uint64, f func(*[4]uint64) ([4]uint64, error)) {
+	for i := 0; i < 16; i++ {
+		x := randLimbs(t, m)
+		r, err := f(&x)
+
+		if err != nil {
+			t.Fatalf("%s(%x): %v", name, x, err)
+		}
+
+		if want := new(big.Int).ModInverse(limbs.ToBig(&x), limbs.ToBig(m)); limbs.ToBig(&r).Cmp(want) != 0 {
+			t.Errorf("%s(%x) = %x, want %x", name, x, limbs.ToBig(&r), want)
+		}
+	}
+
+	if r, err := f(&[4]uint64{}); err != nil || r != ([4]uint64{}) {
+		t.Errorf("%s(0) = %x, %v", name, r, err)
+	}
+
+	// the modulus is reduced to zero
+	if r, err := f(m); err != nil || r != ([4]uint64{}) {
+		t.Errorf("%s(m) = %x, %v", name, r, err)
+	}
+}
+
+func TestSecp256k1FpInv(t *testing.T) {
+	testInv(t, "Secp256k1FpInv", &secp256k1P, Secp256k1FpInv)
+}
+
+func TestSecp256k1FnInv(t *testing.T) {
+	testInv(t, "Secp256k1FnInv", &secp256k1N, Secp256k1FnInv)
+}
+
+func TestBn254FpInv(t *testing.T) {
+	testInv(t, "Bn254FpInv", &bn254P, Bn254FpInv)
+}
+
+func TestSecp256k1FpSqrt(t *testing.T) {
+	var residues, nonResidues int
+
+	for i := 0; i < 32; i++ {
+		x := randLimbs(t, &secp256k1P)
+		parity := uint64(i & 1)
+
+		r, ok, err := Secp256k1FpSqrt(&x, parity)
+
+		if err != nil {
+			t.Fatalf("Secp256k1FpSqrt(%x, %d): %v", x, parity, err)
+		}
+
+		if !ok {
+			nonResidues++
+			continue
+		}
+
+		residues++
+
+		if r[0]&1 != parity {
+			t.Errorf("Secp256k1FpSqrt(%x, %d) = %x, wrong parity", x, parity, r)
+		}
+
+		sq := new(big.Int).Mul(limbs.ToBig(&r), limbs.ToBig(&r))
+
+		if sq.Mod(sq, limbs.ToBig(&secp256k1P)).Cmp(limbs.ToBig(&x)) != 0 {
+			t.Errorf("Secp256k1FpSqrt(%x, %d) = %x, not a root", x, parity, r)
+		}
+	}
+
+	if residues == 0 || nonResidues == 0 {
+		t.Errorf("unexpected distribution of %d residues, %d non-residues", residues, nonResidues)
+	}
+
+	if r, ok, err := Secp256k1FpSqrt(&[4]uint64{}, 1); !ok || err != nil || r != ([4]uint64{}) {
+		t.Errorf("Secp256k1FpSqrt(0) = %x, %v, %v", r, ok, err)
+	}
+}
+
+func TestMsbPos256(t *testing.T) {
+	for _, tc := range []struct {
+		x, y      [4]uint64
+		limb, bit int
+	}{
+		{[4]uint64{1}, [4]uint64{}, 0, 0},
+		{[4]uint64{}, [4]uint64{0, 0, 0x10}, 2, 4},
+		{[4]uint64{0, 0, 0, 1 << 63}, [4]uint64{0, 0, 0, 1}, 3, 63},
+		{[4]uint64{0, 0xff}, [4]uint64{0, 0x100}, 1, 8},
+	} {
+		limb, bit, err := MsbPos256(&tc.x, &tc.y)
+
+		if err != nil || limb != tc.limb || bit != tc.bit {
+			t.Errorf("MsbPos256(%x, %x) = %d, %d, %v, want %d, %d", tc.x, tc.y, limb, bit, err, tc.limb, tc.bit)
+		}
+	}
+
+	if _, _, err := MsbPos256(&[4]uint64{}, &[4]uint64{}); err != ErrZero {
+		t.Errorf("MsbPos256(0, 0) error %v, want %v", err, ErrZero)
+	}
+}
+
+func TestBn254Fp2Inv(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		x := precompile.Complex256{
+			X: randLimbs(t, &bn254P),
+			Y: randLimbs(t, &bn254P),
+		}
+
+		r, err := Bn254Fp2Inv(&x)
+
+		if err != nil {
+			t.Fatalf("Bn254Fp2Inv(%x): %v", x, err)
+		}
+
+		precompile.Bn254ComplexMul(&x, &r)
+
+		if x != (precompile.Complex256{X: [4]uint64{1}}) {
+			t.Errorf("x * Bn254Fp2Inv(x) = %x", x)
+		}
+	}
+}
+
+// addModulus returns v + k*m, a non-canonical representation of v.
+func addModulus(v, m *[4]uint64, k int64) (r [4]uint64) {
+	b := new(big.Int).Mul(limbs.ToBig(m), big.NewInt(k))
+	limbs.FromBig(b.Add(b, limbs.ToBig(v)), &r)
+	return
+}
+
+func TestNonCanonicalInvHint(t *testing.T) {
+	for k := int64(1); k <= 3; k++ {
+		x := randLimbs(t, &bn254P)
+
+		r, err := inv(&x, &bn254P, func(x, r *[4]uint64) {
+			bn254FpInv(x, r)
+			*r = addModulus(r, &bn254P, k)
+		})
+
+		if err != ErrHint || r != ([4]uint64{}) {
+			t.Errorf("inv(%x) with r+%dp hint = %x, %v, want %v", x, k, r, err, ErrHint)
+		}
+
+		f := precompile.Complex256{
+			X: randLimbs(t, &bn254P),
+			Y: randLimbs(t, &bn254P),
+		}
+
+		for _, c := range []func(r *precompile.Complex256){
+			func(r *precompile.Complex256) { r.X = addModulus(&r.X, &bn254P, k) },
+			func(r *precompile.Complex256) { r.Y = addModulus(&r.Y, &bn254P, k) },
+		} {
+			r, err := fp2Inv(&f, func(x, r *precompile.Complex256) {
+				bn254Fp2Inv(x, r)
+				c(r)
+			})
+
+			if err != ErrHint || r != (precompile.Complex256{}) {
+				t.Errorf("fp2Inv(%x) with r+%dp hint = %x, %v, want %v", f, k, r, err, ErrHint)
+			}
+		}
+	}
+}
+
+func TestExpMod(t *testing.T) {
+	var x, e [4]uint64
+
+	for _, v := range []*[4]uint64{&x, &e} {
+		binary.Read(rand.Reader, binary.LittleEndian, v)
+	}
+
+	x = reduce(&x, &secp256k1P)
+	r := expMod(&x, &e, &secp256k1P)
+
+	if want := new(big.Int).Exp(limbs.ToBig(&x), limbs.ToBig(&e), limbs.ToBig(&secp256k1P)); limbs.ToBig(&r).Cmp(want) != 0 {
+		t.Errorf("expMod = %x, want %x", limbs.ToBig(&r), want)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build tamago && riscv64
+
+package fcall
+
+import (
+	"tamagotest/tamaboards/zkvm/precompile"
+)
+
+// defined in fcall_riscv64.s
+
+//go:noescape
+func secp256k1FpInv(x, r *[4]uint64)
+
+//go:noescape
+func secp256k1FnInv(x, r *[4]uint64)
+
+//go:noescape
+func secp256k1FpSqrt(x *[4]uint64, parity uint64, r *[4]uint64) (ok bool)
+
+//go:noescape
+func msbPos256(x, y *[4]uint64) (limb uint64, bit uint64)
+
+//go:noescape
+func bn254FpInv(x, r *[4]uint64)
+
+//go:noescape
+func bn254Fp2Inv(x, r *precompile.Complex256)
@@ -0,0 +1,77 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// ErrInputSize is returned when the input length word does not fit the input
+// window.
+var ErrInputSize = errors.New("zkvm: input length exceeds input window")
+
+// inputSize returns the payload length found in the input window header,
+// excluding the argument header (see hwinit0).
+func inputSize() (int, error) {
+	n := *(*uint64)(unsafe.Pointer(uintptr(INPUT_ADDR + INPUT_SIZE_OFFSET)))
+
+	if n > MAX_INPUT-INPUT_DATA_OFFSET {
+		return 0, ErrInputSize
+	}
+
+	if argsInvalid {
+		return 0, ErrArgs
+	}
+
+	return int(n) - argsLength, nil
+}
+
+// Input returns the program input supplied by the emulator.
+//
+// The returned slice aliases the read-only input window and must not be
+// modified. Input panics if the input header is malformed.
+func Input() []byte {
+	n, err := inputSize()
+
+	if err != nil {
+		panic(err)
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	return unsafe.Slice((*byte)(unsafe.Pointer(uintptr(INPUT_ADDR+INPUT_DATA_OFFSET+argsLength))), n)
+}
+
+// InputReader returns an io.Reader positioned at the start of the program
+// input.
+func InputReader() io.Reader {
+	return bytes.NewReader(Input())
+}
+
+// ReadInput decodes a value of type T from the start of the program input.
+//
+// The input is decoded in little-endian byte order with encoding/binary, T
+// must therefore be a fixed-size type (see binary.Size).
+func ReadInput[T any]() (v T, err error) {
+	n, err := inputSize()
+
+	if err != nil {
+		return
+	}
+
+	if size := binary.Size(v); size < 0 {
+		return v, errors.New("zkvm: input type is not fixed-size")
+	} else if size > n {
+		return v, io.ErrUnexpectedEOF
+	}
+
+	err = binary.Read(InputReader(), binary.LittleEndian, &v)
+
+	return
+}
@@ -0,0 +1,31 @@
+// Package limbs converts the 256-bit values used by the precompile and fcall
+// packages, represented as four little-endian uint64 limbs, to and from
+// big.Int for their pure-Go implementations and tests.
+package limbs
+
+import (
+	"math/big"
+)
+
+// ToBig returns the value of v as a big.Int.
+func ToBig(v *[4]uint64) *big.Int {
+	b := new(big.Int)
+
+	for i := 3; i >= 0; i-- {
+		b.Lsh(b, 64)
+		b.Or(b, new(big.Int).SetUint64(v[i]))
+	}
+
+	return b
+}
+
+// FromBig sets v to the low 256 bits of b, which must not be negative.
+func FromBig(b *big.Int, v *[4]uint64) {
+	w := new(big.Int).Set(b)
+	mask := new(big.Int).SetUint64(^uint64(0))
+
+	for i := 0; i < 4; i++ {
+		v[i] = new(big.Int).And(w, mask).Uint64()
+		w.Rsh(w, 64)
+	}
+}
@@ -0,0 +1,22 @@
+package zkvm
+
+import "fmt"
+
+// LinkFlags returns the linker flags (-ldflags) placing a program in the board
+// memory map, as used by the Makefile (see cmd/ldflags): text and data
+// addresses and the memory regions the linker checks the program layout
+// against.
+func LinkFlags() string {
+	return fmt.Sprintf("-T %#x -R %#x -D %#x"+
+		" -region rom=rom:%#x:%#x"+
+		" -region input=reserved:%#x:%#x"+
+		" -region ram=ram:%#x:%#x"+
+		" -region sys=reserved:%#x:%#x"+
+		" -region output=reserved:%#x:%#x",
+		TEXT_ADDR, PAGE_SIZE, DATA_ADDR,
+		ROM_ADDR, ROM_SIZE,
+		INPUT_ADDR, MAX_INPUT,
+		SYS_ADDR, RAM_MAX_SIZE,
+		SYS_ADDR, SYS_SIZE,
+		OUTPUT_ADDR, OUTPUT_MAX_SIZE)
+}
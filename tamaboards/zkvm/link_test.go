@@ -0,0 +1,62 @@
+//go:build !(tamago && riscv64)
+
+package zkvm
+
+import (
+	"debug/elf"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestLinkTestBinary links the test binaries of a program importing the board,
+// which then provides the runtime hooks in place of the testing package (zkvm
+// build tag), and of a standard library package, which gets them from the ZisK
+// testing board of the testing package (zkvm_testing build tag).
+func TestLinkTestBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	goTool, err := filepath.Abs("../../tamago-go-latest/bin/go")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(goTool); err != nil {
+		t.Skip("tamago toolchain not built")
+	}
+
+	for _, test := range []struct {
+		pkg string
+		tag string
+	}{
+		{"../../tama-programs/empty", "zkvm"},
+		{"strings", "zkvm_testing"},
+	} {
+		t.Run(test.tag, func(t *testing.T) {
+			bin := filepath.Join(t.TempDir(), "pkg.test")
+
+			cmd := exec.Command(goTool, "test", "-c", "-tags", test.tag, "-ldflags", LinkFlags(), "-o", bin, test.pkg)
+			cmd.Env = append(os.Environ(), "GOOS=tamago", "GOARCH=riscv64", "GORISCV64=rv64ima")
+
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("%v: %v\n%s", cmd, err, out)
+			}
+
+			f, err := elf.Open(bin)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer f.Close()
+
+			if s := f.Section(".text"); s == nil || s.Addr != TEXT_ADDR {
+				t.Errorf(".text section %+v, want address %#x", s, TEXT_ADDR)
+			}
+		})
+	}
+}
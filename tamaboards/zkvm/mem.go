@@ -0,0 +1,86 @@
+package zkvm
+
+// ZisK memory map, see zisk/core/src/mem.rs.
+//
+// These constants are the single definition of the board memory map, they
+// are exported to the board assembly through go_asm.h and to host tools,
+// LinkFlags derives from them the linker text address (-T), rounding quantum
+// (-R), data address (-D) and memory regions (-region) used by the Makefile.
+const (
+	// ROM_ADDR is the address of the first program instruction.
+	ROM_ADDR = 0x80000000
+	// ROM_SIZE is the size of the ROM (128 MiB).
+	ROM_SIZE = 0x8000000
+
+	// PAGE_SIZE is the rounding quantum of the program headers (-R).
+	PAGE_SIZE = 0x1000
+	// TEXT_ADDR is the link address of the text (-T), one page into the
+	// ROM as the text program header also maps the ELF headers before it.
+	TEXT_ADDR = ROM_ADDR + PAGE_SIZE
+
+	// INPUT_ADDR is the start of the read-only input window, the emulator
+	// fills it with a free input word, a length word and the payload.
+	INPUT_ADDR = 0x90000000
+	// MAX_INPUT is the size of the input window, including its header.
+	MAX_INPUT = 0x2000
+
+	// INPUT_SIZE_OFFSET is the offset of the little-endian uint64 payload
+	// length within the input window.
+	INPUT_SIZE_OFFSET = 8
+	// INPUT_DATA_OFFSET is the offset of the payload within the input
+	// window.
+	INPUT_DATA_OFFSET = 16
+
+	// SYS_ADDR is the start of the system RW memory.
+	SYS_ADDR = 0xa0000000
+	// SYS_SIZE is the size of the system memory.
+	SYS_SIZE = 0x10000
+	// UART_ADDR receives single byte stores which the emulator copies to
+	// its standard output.
+	UART_ADDR = SYS_ADDR + 512
+
+	// FATAL_ADDR is the start of the fatal error report region, carved out
+	// of the system memory not used by registers, UART and CSRs.
+	FATAL_ADDR = SYS_ADDR + 0x1000
+	// FATAL_SIZE is the size of the fatal error report region.
+	FATAL_SIZE = 0x1000
+
+	// OUTPUT_ADDR is the start of the public output window, it holds a
+	// uint32 output count followed by 32-bit output slots.
+	OUTPUT_ADDR = 0xa0010000
+	// OUTPUT_MAX_SIZE is the size of the output window, including its
+	// count word.
+	OUTPUT_MAX_SIZE = 0x10000
+
+	// RAM_ADDR is the start of the ZisK RAM, which begins with the system
+	// memory and the output window.
+	RAM_ADDR = SYS_ADDR
+	// RAM_MAX_SIZE is the size of the ZisK RAM (512 MiB).
+	RAM_MAX_SIZE = 0x20000000
+	// RAM_END is the end of the ZisK RAM.
+	RAM_END = RAM_ADDR + RAM_MAX_SIZE
+
+	// RAM_START is the start of the RAM available to the Go runtime,
+	// following the output window.
+	RAM_START = OUTPUT_ADDR + OUTPUT_MAX_SIZE
+	// RAM_SIZE is the size of the RAM available to the Go runtime.
+	RAM_SIZE = RAM_END - RAM_START
+
+	// DATA_ADDR is the start of the Go data and bss, at the beginning of
+	// the runtime RAM. Their initial contents are loaded in ROM, after the
+	// read-only data, and copied by the runtime at boot.
+	DATA_ADDR = RAM_START
+	// DATA_SIZE is the size reserved for the Go data and bss.
+	DATA_SIZE = 0x1000000
+
+	// STACK_OFFSET is reserved at the end of RAM, the initial stack pointer
+	// is placed right below it and the stack grows down towards the heap.
+	STACK_OFFSET = 0x100000
+	// G0_STACK_SIZE is the size of the runtime system (g0) stack, at the
+	// top of the stack area, overflowing it is a fatal error rather than
+	// a write to the heap below.
+	G0_STACK_SIZE = 0x10000
+	// HEAP_START is the start of the heap, following the data and bss,
+	// which grows up towards the stack.
+	HEAP_START = DATA_ADDR + DATA_SIZE
+)
@@ -0,0 +1,17 @@
+//go:build tamago && riscv64 && zkvm_nogc
+
+package zkvm
+
+import (
+	_ "unsafe"
+)
+
+// Building with the zkvm_nogc tag disables the garbage collector, every
+// allocation is served by bumping the heap break and is never reclaimed.
+//
+// Guests are short-lived batch computations where each GC cycle is pure
+// proving cost, this mode trades RAM for cycles: programs whose allocations
+// exceed the board RAM terminate with EXIT_OOM.
+
+//go:linkname noGC runtime.NoGC
+var noGC = true
@@ -0,0 +1,67 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// MAX_OUTPUTS is the number of 32-bit public output slots which fit in the
+// output window after its count word.
+const MAX_OUTPUTS = (OUTPUT_MAX_SIZE - 4) / 4
+
+// ErrOutputSize is returned when a public output does not fit the output
+// window.
+var ErrOutputSize = errors.New("zkvm: output exceeds output window")
+
+func outputCount() *uint32 {
+	return (*uint32)(unsafe.Pointer(uintptr(OUTPUT_ADDR)))
+}
+
+func outputSlot(id int) *uint32 {
+	return (*uint32)(unsafe.Pointer(uintptr(OUTPUT_ADDR + 4 + 4*id)))
+}
+
+// SetOutput sets public output slot id to v, extending the output count to
+// include it (as ziskos set_output).
+func SetOutput(id int, v uint32) error {
+	if id < 0 || id >= MAX_OUTPUTS {
+		return ErrOutputSize
+	}
+
+	if n := outputCount(); uint32(id+1) > *n {
+		*n = uint32(id + 1)
+	}
+
+	*outputSlot(id) = v
+
+	return nil
+}
+
+// Commit appends b to the public output, packed in little-endian 32-bit
+// slots following the last slot in use, the final slot is zero padded.
+//
+// Commit fails without writing anything if b does not fit the output window.
+func Commit(b []byte) error {
+	n := int(*outputCount())
+	slots := (len(b) + 3) / 4
+
+	if n+slots > MAX_OUTPUTS {
+		return ErrOutputSize
+	}
+
+	for i := 0; i < slots; i++ {
+		var v uint32
+
+		for j := 0; j < 4 && i*4+j < len(b); j++ {
+			v |= uint32(b[i*4+j]) << (8 * j)
+		}
+
+		*outputSlot(n + i) = v
+	}
+
+	*outputCount() = uint32(n + slots)
+
+	return nil
+}
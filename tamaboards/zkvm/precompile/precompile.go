@@ -0,0 +1,172 @@
+// Package precompile provides Go bindings for the ZisK precompile syscalls.
+//
+// On the zkVM (tamago && riscv64) each function is a CSR set on the
+// precompile port (0x800-0x80A), which the ZisK transpiler replaces with the
+// matching precompiled operation. On any other build a pure-Go implementation
+// is used, so that the same code runs in unit tests on the host.
+//
+// 256-bit values are represented as four little-endian uint64 limbs. All data
+// passed to the precompiles must be aligned to an 8-byte boundary, as
+// required by ziskos, functions panic with ErrUnaligned otherwise.
+package precompile
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Precompile syscall ports, see ziskos syscalls.
+const (
+	SYSCALL_KECCAKF_ID           = 0x800
+	SYSCALL_ARITH256_ID          = 0x801
+	SYSCALL_ARITH256_MOD_ID      = 0x802
+	SYSCALL_SECP256K1_ADD_ID     = 0x803
+	SYSCALL_SECP256K1_DBL_ID     = 0x804
+	SYSCALL_SHA256F_ID           = 0x805
+	SYSCALL_BN254_CURVE_ADD_ID   = 0x806
+	SYSCALL_BN254_CURVE_DBL_ID   = 0x807
+	SYSCALL_BN254_COMPLEX_ADD_ID = 0x808
+	SYSCALL_BN254_COMPLEX_SUB_ID = 0x809
+	SYSCALL_BN254_COMPLEX_MUL_ID = 0x80A
+)
+
+// ErrUnaligned is raised when precompile data is not aligned to an 8-byte
+// boundary.
+var ErrUnaligned = errors.New("precompile: data not aligned to 8 bytes")
+
+// Point256 represents an affine elliptic curve point with 256-bit
+// coordinates.
+type Point256 struct {
+	X [4]uint64
+	Y [4]uint64
+}
+
+// Complex256 represents an element of a quadratic extension (X + Y·i) of a
+// 256-bit prime field.
+type Complex256 struct {
+	X [4]uint64
+	Y [4]uint64
+}
+
+// syscall parameter blocks, matching the ziskos repr(C) layouts
+
+type arith256Params struct {
+	a  *[4]uint64
+	b  *[4]uint64
+	c  *[4]uint64
+	dl *[4]uint64
+	dh *[4]uint64
+}
+
+type arith256ModParams struct {
+	a      *[4]uint64
+	b      *[4]uint64
+	c      *[4]uint64
+	module *[4]uint64
+	d      *[4]uint64
+}
+
+type pointParams struct {
+	p1 *Point256
+	p2 *Point256
+}
+
+type sha256Params struct {
+	state *[4]uint64
+	input *[8]uint64
+}
+
+type complexParams struct {
+	f1 *Complex256
+	f2 *Complex256
+}
+
+func aligned[T any](p ...*T) {
+	for _, v := range p {
+		if uintptr(unsafe.Pointer(v))&7 != 0 {
+			panic(ErrUnaligned)
+		}
+	}
+}
+
+// KeccakF executes the Keccak-f[1600] permutation on state, in place.
+func KeccakF(state *[25]uint64) {
+	aligned(state)
+	keccakf(state)
+}
+
+// Arith256 computes the 512-bit result of a * b + c, storing its least
+// significant half in dl and its most significant half in dh.
+func Arith256(a, b, c, dl, dh *[4]uint64) {
+	aligned(a, b, c, dl, dh)
+	arith256(&arith256Params{a, b, c, dl, dh})
+}
+
+// Arith256Mod computes d = (a * b + c) mod module.
+func Arith256Mod(a, b, c, module, d *[4]uint64) {
+	aligned(a, b, c, module, d)
+	arith256Mod(&arith256ModParams{a, b, c, module, d})
+}
+
+// Secp256k1Add adds p2 to p1 on the secp256k1 curve, storing the result in
+// p1. The points must be distinct, not opposite, and have coordinates within
+// the secp256k1 base field.
+func Secp256k1Add(p1, p2 *Point256) {
+	aligned(p1, p2)
+	secp256k1Add(&pointParams{p1, p2})
+}
+
+// Secp256k1Dbl doubles p on the secp256k1 curve, in place. The coordinates of
+// p must be within the secp256k1 base field.
+func Secp256k1Dbl(p *Point256) {
+	aligned(p)
+	secp256k1Dbl(p)
+}
+
+// Sha256F executes the SHA-256 extend and compress function of the 512-bit
+// input block on state, in place.
+//
+// The state holds the eight 32-bit SHA-256 words in pairs, most significant
+// first (state[0] = h0<<32 | h1), the input holds the block as big-endian
+// 64-bit words.
+func Sha256F(state *[4]uint64, input *[8]uint64) {
+	aligned(state)
+	aligned(input)
+	sha256f(&sha256Params{state, input})
+}
+
+// Bn254CurveAdd adds p2 to p1 on the BN254 curve, storing the result in p1.
+// The points must be distinct, not opposite, and have coordinates within the
+// BN254 base field.
+func Bn254CurveAdd(p1, p2 *Point256) {
+	aligned(p1, p2)
+	bn254CurveAdd(&pointParams{p1, p2})
+}
+
+// Bn254CurveDbl doubles p on the BN254 curve, in place. The coordinates of p
+// must be within the BN254 base field.
+func Bn254CurveDbl(p *Point256) {
+	aligned(p)
+	bn254CurveDbl(p)
+}
+
+// Bn254ComplexAdd computes f1 = f1 + f2 over the BN254 base field quadratic
+// extension.
+func Bn254ComplexAdd(f1, f2 *Complex256) {
+	aligned(f1, f2)
+	bn254ComplexAdd(&complexParams{f1, f2})
+}
+
+// Bn254ComplexSub computes f1 = f1 - f2 over the BN254 base field quadratic
+// extension.
+func Bn254ComplexSub(f1, f2 *Complex256) {
+	aligned(f1, f2)
+	bn254ComplexSub(&complexParams{f1, f2})
+}
+
+// Bn254ComplexMul computes f1 = f1 * f2 over the BN254 base field quadratic
+// extension.
+func Bn254ComplexMul(f1, f2 *Complex256) {
+	aligned(f1, f2)
+	bn254ComplexMul(&complexParams{f1, f2})
+}
@@ -0,0 +1,236 @@
+//go:build !(tamago && riscv64)
+
+package precompile
+
+import (
+	"math/big"
+	"math/bits"
+
+	"tamagotest/tamaboards/zkvm/internal/limbs"
+)
+
+// Pure-Go implementations of the ZisK precompiles, for host builds.
+
+var (
+	secp256k1P, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	bn254P, _     = new(big.Int).SetString("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47", 16)
+)
+
+var keccakRC = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotc = [24]int{
+	1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44,
+}
+
+var keccakPiln = [24]int{
+	10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1,
+}
+
+func keccakf(a *[25]uint64) {
+	var bc [5]uint64
+
+	for round := 0; round < 24; round++ {
+		// theta
+		for i := 0; i < 5; i++ {
+			bc[i] = a[i] ^ a[i+5] ^ a[i+10] ^ a[i+15] ^ a[i+20]
+		}
+
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ bits.RotateLeft64(bc[(i+1)%5], 1)
+
+			for j := 0; j < 25; j += 5 {
+				a[j+i] ^= t
+			}
+		}
+
+		// rho and pi
+		t := a[1]
+
+		for i := 0; i < 24; i++ {
+			j := keccakPiln[i]
+			bc[0] = a[j]
+			a[j] = bits.RotateLeft64(t, keccakRotc[i])
+			t = bc[0]
+		}
+
+		// chi
+		for j := 0; j < 25; j += 5 {
+			for i := 0; i < 5; i++ {
+				bc[i] = a[j+i]
+			}
+
+			for i := 0; i < 5; i++ {
+				a[j+i] ^= ^bc[(i+1)%5] & bc[(i+2)%5]
+			}
+		}
+
+		// iota
+		a[0] ^= keccakRC[round]
+	}
+}
+
+func arith256(p *arith256Params) {
+	d := new(big.Int).Mul(limbs.ToBig(p.a), limbs.ToBig(p.b))
+	d.Add(d, limbs.ToBig(p.c))
+
+	limbs.FromBig(d, p.dl)
+	limbs.FromBig(d.Rsh(d, 256), p.dh)
+}
+
+func arith256Mod(p *arith256ModParams) {
+	d := new(big.Int).Mul(limbs.ToBig(p.a), limbs.ToBig(p.b))
+	d.Add(d, limbs.ToBig(p.c))
+	d.Mod(d, limbs.ToBig(p.module))
+
+	limbs.FromBig(d, p.d)
+}
+
+// curveAdd computes p1 = p1 + p2 on a short Weierstrass curve with a = 0,
+// doubling when dbl is set.
+func curveAdd(m *big.Int, p1, p2 *Point256, dbl bool) {
+	x1, y1 := limbs.ToBig(&p1.X), limbs.ToBig(&p1.Y)
+	x2, y2 := limbs.ToBig(&p2.X), limbs.ToBig(&p2.Y)
+
+	var s *big.Int
+
+	if dbl {
+		// s = 3x1² / 2y1
+		num := new(big.Int).Mul(x1, x1)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(y1, 1)
+		s = num.Mul(num, den.ModInverse(den.Mod(den, m), m))
+	} else {
+		// s = (y2 - y1) / (x2 - x1)
+		num := new(big.Int).Sub(y2, y1)
+		den := new(big.Int).Sub(x2, x1)
+		s = num.Mul(num, den.ModInverse(den.Mod(den, m), m))
+	}
+
+	s.Mod(s, m)
+
+	// x3 = s² - x1 - x2
+	x3 := new(big.Int).Mul(s, s)
+	x3.Sub(x3, x1)
+	x3.Sub(x3, x2)
+	x3.Mod(x3, m)
+
+	// y3 = s(x1 - x3) - y1
+	y3 := new(big.Int).Sub(x1, x3)
+	y3.Mul(y3, s)
+	y3.Sub(y3, y1)
+	y3.Mod(y3, m)
+
+	limbs.FromBig(x3, &p1.X)
+	limbs.FromBig(y3, &p1.Y)
+}
+
+func secp256k1Add(p *pointParams) {
+	curveAdd(secp256k1P, p.p1, p.p2, false)
+}
+
+func secp256k1Dbl(p *Point256) {
+	curveAdd(secp256k1P, p, p, true)
+}
+
+func bn254CurveAdd(p *pointParams) {
+	curveAdd(bn254P, p.p1, p.p2, false)
+}
+
+func bn254CurveDbl(p *Point256) {
+	curveAdd(bn254P, p, p, true)
+}
+
+var sha256K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+func sha256f(p *sha256Params) {
+	var h [8]uint32
+	var w [64]uint32
+
+	for i := 0; i < 4; i++ {
+		h[2*i] = uint32(p.state[i] >> 32)
+		h[2*i+1] = uint32(p.state[i])
+	}
+
+	for i := 0; i < 8; i++ {
+		w[2*i] = uint32(p.input[i] >> 32)
+		w[2*i+1] = uint32(p.input[i])
+	}
+
+	for i := 16; i < 64; i++ {
+		s0 := bits.RotateLeft32(w[i-15], -7) ^ bits.RotateLeft32(w[i-15], -18) ^ (w[i-15] >> 3)
+		s1 := bits.RotateLeft32(w[i-2], -17) ^ bits.RotateLeft32(w[i-2], -19) ^ (w[i-2] >> 10)
+		w[i] = w[i-16] + s0 + w[i-7] + s1
+	}
+
+	a, b, c, d, e, f, g, hh := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+
+	for i := 0; i < 64; i++ {
+		s1 := bits.RotateLeft32(e, -6) ^ bits.RotateLeft32(e, -11) ^ bits.RotateLeft32(e, -25)
+		ch := (e & f) ^ (^e & g)
+		t1 := hh + s1 + ch + sha256K[i] + w[i]
+		s0 := bits.RotateLeft32(a, -2) ^ bits.RotateLeft32(a, -13) ^ bits.RotateLeft32(a, -22)
+		maj := (a & b) ^ (a & c) ^ (b & c)
+		t2 := s0 + maj
+
+		hh, g, f, e, d, c, b, a = g, f, e, d+t1, c, b, a, t1+t2
+	}
+
+	h[0] += a
+	h[1] += b
+	h[2] += c
+	h[3] += d
+	h[4] += e
+	h[5] += f
+	h[6] += g
+	h[7] += hh
+
+	for i := 0; i < 4; i++ {
+		p.state[i] = uint64(h[2*i])<<32 | uint64(h[2*i+1])
+	}
+}
+
+func bn254ComplexAdd(p *complexParams) {
+	x := new(big.Int).Add(limbs.ToBig(&p.f1.X), limbs.ToBig(&p.f2.X))
+	y := new(big.Int).Add(limbs.ToBig(&p.f1.Y), limbs.ToBig(&p.f2.Y))
+
+	limbs.FromBig(x.Mod(x, bn254P), &p.f1.X)
+	limbs.FromBig(y.Mod(y, bn254P), &p.f1.Y)
+}
+
+func bn254ComplexSub(p *complexParams) {
+	x := new(big.Int).Sub(limbs.ToBig(&p.f1.X), limbs.ToBig(&p.f2.X))
+	y := new(big.Int).Sub(limbs.ToBig(&p.f1.Y), limbs.ToBig(&p.f2.Y))
+
+	limbs.FromBig(x.Mod(x, bn254P), &p.f1.X)
+	limbs.FromBig(y.Mod(y, bn254P), &p.f1.Y)
+}
+
+func bn254ComplexMul(p *complexParams) {
+	a, b := limbs.ToBig(&p.f1.X), limbs.ToBig(&p.f1.Y)
+	c, d := limbs.ToBig(&p.f2.X), limbs.ToBig(&p.f2.Y)
+
+	// (a + bi)(c + di) = (ac - bd) + (ad + bc)i
+	x := new(big.Int).Mul(a, c)
+	x.Sub(x, new(big.Int).Mul(b, d))
+	y := new(big.Int).Mul(a, d)
+	y.Add(y, new(big.Int).Mul(b, c))
+
+	limbs.FromBig(x.Mod(x, bn254P), &p.f1.X)
+	limbs.FromBig(y.Mod(y, bn254P), &p.f1.Y)
+}
@@ -0,0 +1,73 @@
+//go:build tamago && riscv64
+
+#include "textflag.h"
+
+// Each precompile is invoked with `csrs <port>, a0` (csrrs zero, <port>, a0)
+// where A0 holds the address of its parameters, the ZisK transpiler replaces
+// the CSR set with the precompiled operation.
+
+// func keccakf(state *[25]uint64)
+TEXT ·keccakf(SB),NOSPLIT,$0-8
+	MOV	state+0(FP), A0
+	CSRRS	A0, $0x800, ZERO
+	RET
+
+// func arith256(params *arith256Params)
+TEXT ·arith256(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x801, ZERO
+	RET
+
+// func arith256Mod(params *arith256ModParams)
+TEXT ·arith256Mod(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x802, ZERO
+	RET
+
+// func secp256k1Add(params *pointParams)
+TEXT ·secp256k1Add(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x803, ZERO
+	RET
+
+// func secp256k1Dbl(p *Point256)
+TEXT ·secp256k1Dbl(SB),NOSPLIT,$0-8
+	MOV	p+0(FP), A0
+	CSRRS	A0, $0x804, ZERO
+	RET
+
+// func sha256f(params *sha256Params)
+TEXT ·sha256f(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x805, ZERO
+	RET
+
+// func bn254CurveAdd(params *pointParams)
+TEXT ·bn254CurveAdd(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x806, ZERO
+	RET
+
+// func bn254CurveDbl(p *Point256)
+TEXT ·bn254CurveDbl(SB),NOSPLIT,$0-8
+	MOV	p+0(FP), A0
+	CSRRS	A0, $0x807, ZERO
+	RET
+
+// func bn254ComplexAdd(params *complexParams)
+TEXT ·bn254ComplexAdd(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x808, ZERO
+	RET
+
+// func bn254ComplexSub(params *complexParams)
+TEXT ·bn254ComplexSub(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x809, ZERO
+	RET
+
+// func bn254ComplexMul(params *complexParams)
+TEXT ·bn254ComplexMul(SB),NOSPLIT,$0-8
+	MOV	params+0(FP), A0
+	CSRRS	A0, $0x80a, ZERO
+	RET
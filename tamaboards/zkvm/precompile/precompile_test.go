@@ -0,0 +1,204 @@
+//go:build !(tamago && riscv64)
+
+package precompile
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"unsafe"
+
+	"tamagotest/tamaboards/zkvm/internal/limbs"
+)
+
+func hexLimbs(t *testing.T, s string) (v [4]uint64) {
+	b, ok := new(big.Int).SetString(s, 16)
+
+	if !ok {
+		t.Fatalf("invalid hex %s", s)
+	}
+
+	limbs.FromBig(b, &v)
+
+	return
+}
+
+func TestKeccakF(t *testing.T) {
+	var state [25]uint64
+
+	KeccakF(&state)
+
+	if state[0] != 0xf1258f7940e1dde7 || state[24] != 0xeaf1ff7b5ceca249 {
+		t.Errorf("unexpected permutation of zero state %#x %#x", state[0], state[24])
+	}
+}
+
+func TestSha256F(t *testing.T) {
+	msg := []byte("abc")
+
+	var block [64]byte
+	copy(block[:], msg)
+	block[len(msg)] = 0x80
+	binary.BigEndian.PutUint64(block[56:], uint64(len(msg)*8))
+
+	state := [4]uint64{
+		0x6a09e667bb67ae85,
+		0x3c6ef372a54ff53a,
+		0x510e527f9b05688c,
+		0x1f83d9ab5be0cd19,
+	}
+
+	var input [8]uint64
+
+	for i := range input {
+		input[i] = binary.BigEndian.Uint64(block[i*8:])
+	}
+
+	Sha256F(&state, &input)
+
+	var digest [32]byte
+
+	for i, v := range state {
+		binary.BigEndian.PutUint64(digest[i*8:], v)
+	}
+
+	if want := sha256.Sum256(msg); digest != want {
+		t.Errorf("digest %x, want %x", digest, want)
+	}
+}
+
+func TestArith256(t *testing.T) {
+	var a, b, c, m, dl, dh, d [4]uint64
+
+	for _, v := range []*[4]uint64{&a, &b, &c, &m} {
+		binary.Read(rand.Reader, binary.LittleEndian, v)
+	}
+
+	want := new(big.Int).Mul(limbs.ToBig(&a), limbs.ToBig(&b))
+	want.Add(want, limbs.ToBig(&c))
+
+	Arith256(&a, &b, &c, &dl, &dh)
+
+	got := new(big.Int).Lsh(limbs.ToBig(&dh), 256)
+	got.Or(got, limbs.ToBig(&dl))
+
+	if got.Cmp(want) != 0 {
+		t.Errorf("arith256 %x, want %x", got, want)
+	}
+
+	Arith256Mod(&a, &b, &c, &m, &d)
+
+	if want.Mod(want, limbs.ToBig(&m)); limbs.ToBig(&d).Cmp(want) != 0 {
+		t.Errorf("arith256_mod %x, want %x", limbs.ToBig(&d), want)
+	}
+}
+
+func TestSecp256k1(t *testing.T) {
+	g := Point256{
+		X: hexLimbs(t, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		Y: hexLimbs(t, "483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+	}
+
+	p := g
+	Secp256k1Dbl(&p)
+
+	g2 := Point256{
+		X: hexLimbs(t, "c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5"),
+		Y: hexLimbs(t, "1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a"),
+	}
+
+	if p != g2 {
+		t.Fatalf("2G = %x, want %x", p, g2)
+	}
+
+	Secp256k1Add(&p, &g)
+
+	g3 := Point256{
+		X: hexLimbs(t, "f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9"),
+		Y: hexLimbs(t, "388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672"),
+	}
+
+	if p != g3 {
+		t.Errorf("3G = %x, want %x", p, g3)
+	}
+}
+
+func TestBn254Curve(t *testing.T) {
+	g := Point256{X: [4]uint64{1}, Y: [4]uint64{2}}
+
+	// 4G = 2(2G)
+	a := g
+	Bn254CurveDbl(&a)
+	Bn254CurveDbl(&a)
+
+	// 4G = ((2G + G) + G)
+	b := g
+	Bn254CurveDbl(&b)
+	Bn254CurveAdd(&b, &g)
+	Bn254CurveAdd(&b, &g)
+
+	if a != b {
+		t.Fatalf("2(2G) = %x, 2G+G+G = %x", a, b)
+	}
+
+	// y² = x³ + 3
+	x, y := limbs.ToBig(&a.X), limbs.ToBig(&a.Y)
+	lhs := new(big.Int).Mul(y, y)
+	rhs := new(big.Int).Exp(x, big.NewInt(3), nil)
+	rhs.Add(rhs, big.NewInt(3))
+
+	if lhs.Mod(lhs, bn254P).Cmp(rhs.Mod(rhs, bn254P)) != 0 {
+		t.Errorf("4G is not on the curve")
+	}
+}
+
+func TestBn254Complex(t *testing.T) {
+	i := Complex256{Y: [4]uint64{1}}
+	one := Complex256{X: [4]uint64{1}}
+
+	// i² = -1
+	f := i
+	Bn254ComplexMul(&f, &i)
+
+	var minusOne Complex256
+	limbs.FromBig(new(big.Int).Sub(bn254P, big.NewInt(1)), &minusOne.X)
+
+	if f != minusOne {
+		t.Fatalf("i² = %x, want %x", f, minusOne)
+	}
+
+	// -1 + 1 = 0
+	Bn254ComplexAdd(&f, &one)
+
+	if f != (Complex256{}) {
+		t.Errorf("-1 + 1 = %x", f)
+	}
+
+	// 0 - i = -i
+	Bn254ComplexSub(&f, &i)
+
+	var minusI Complex256
+	limbs.FromBig(new(big.Int).Sub(bn254P, big.NewInt(1)), &minusI.Y)
+
+	if f != minusI {
+		t.Errorf("0 - i = %x, want %x", f, minusI)
+	}
+}
+
+func TestAlignment(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrUnaligned {
+			t.Errorf("unexpected recover value %v", r)
+		}
+	}()
+
+	var buf [80]byte
+
+	// offset buf to an address which is 4 modulo 8
+	off := (12 - int(uintptr(unsafe.Pointer(&buf[0]))%8)) % 8
+	p := (*Point256)(unsafe.Add(unsafe.Pointer(&buf[0]), off))
+
+	Secp256k1Dbl(p)
+}
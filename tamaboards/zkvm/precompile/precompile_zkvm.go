@@ -0,0 +1,38 @@
+//go:build tamago && riscv64
+
+package precompile
+
+// defined in precompile_riscv64.s
+
+//go:noescape
+func keccakf(state *[25]uint64)
+
+//go:noescape
+func arith256(params *arith256Params)
+
+//go:noescape
+func arith256Mod(params *arith256ModParams)
+
+//go:noescape
+func secp256k1Add(params *pointParams)
+
+//go:noescape
+func secp256k1Dbl(p *Point256)
+
+//go:noescape
+func sha256f(params *sha256Params)
+
+//go:noescape
+func bn254CurveAdd(params *pointParams)
+
+//go:noescape
+func bn254CurveDbl(p *Point256)
+
+//go:noescape
+func bn254ComplexAdd(params *complexParams)
+
+//go:noescape
+func bn254ComplexSub(params *complexParams)
+
+//go:noescape
+func bn254ComplexMul(params *complexParams)
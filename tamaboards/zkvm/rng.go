@@ -0,0 +1,100 @@
+//go:build tamago && riscv64
+
+package zkvm
+
+import (
+	"math/rand/v2"
+	_ "unsafe"
+)
+
+// Random data is generated deterministically, so that runs are reproducible,
+// by expanding a seed with ChaCha8. The seed is configured through the
+// environment, either at link time (-ldflags -env) or from the input argument
+// header, the latter taking precedence:
+//
+//	ZKVM_SEED                      32 bytes seed, hex encoded
+//	ZKVM_UNSAFE_DETERMINISTIC_RNG  set to 1 to use a fixed, public, seed
+//
+// Random data is only as secret as its seed, which is known to the prover.
+//
+// The runtime (hash seeds, math/rand) always draws from the generator, using
+// the fixed seed if none is configured. Any other use of random data (e.g.
+// crypto/rand) without a seed, or the unsafe opt-in, is a fatal error.
+var rng struct {
+	gen rand.ChaCha8
+
+	init bool
+	// seeded is set when ZKVM_SEED is set or unsafe use is opted in
+	seeded bool
+	// runtime is set while the runtime is reading its own seed
+	runtime bool
+}
+
+//go:linkname throw runtime.throw
+func throw(s string)
+
+// seedRNG seeds the generator, it runs before the runtime is initialized and
+// must not allocate.
+func seedRNG() {
+	var seed [32]byte
+
+	rng.init = true
+
+	switch s := getenv("ZKVM_SEED"); {
+	case s != "":
+		if len(s) != 2*len(seed) {
+			throw("zkvm: invalid ZKVM_SEED length")
+		}
+
+		for i := range seed {
+			hi, ok1 := unhex(s[2*i])
+			lo, ok2 := unhex(s[2*i+1])
+
+			if !ok1 || !ok2 {
+				throw("zkvm: invalid ZKVM_SEED")
+			}
+
+			seed[i] = hi<<4 | lo
+		}
+
+		rng.seeded = true
+	case getenv("ZKVM_UNSAFE_DETERMINISTIC_RNG") == "1":
+		rng.seeded = true
+	}
+
+	rng.gen.Seed(seed)
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+
+	return 0, false
+}
+
+// initRNG is only invoked by the runtime before reading its own seed.
+//
+//go:linkname initRNG runtime.initRNG
+func initRNG() {
+	rng.runtime = true
+}
+
+//go:linkname getRandomData runtime.getRandomData
+func getRandomData(b []byte) {
+	if !rng.init {
+		seedRNG()
+	}
+
+	if !rng.seeded && !rng.runtime {
+		throw("zkvm: random data requested without a seed, set ZKVM_SEED or ZKVM_UNSAFE_DETERMINISTIC_RNG=1")
+	}
+
+	rng.runtime = false
+	rng.gen.Read(b)
+}
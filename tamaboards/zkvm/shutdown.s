@@ -2,8 +2,9 @@
 
 #include "textflag.h"
 
-// Shutdown triggers ZisK exit via ecall with a7=93
-TEXT ·Shutdown(SB),NOSPLIT|NOFRAME,$0
+// exit triggers ZisK exit via ecall with a7=93, passing the status in A0
+TEXT ·exit(SB),NOSPLIT|NOFRAME,$0-4
+	MOVW	code+0(FP), A0
 	MOV	$93, A7		// CAUSE_EXIT = 93
 	ECALL			// System call to exit
 	RET			// Should never reach here
@@ -26,3 +26,14 @@ func IsRISCV64AMO(op obj.As) bool {
 	}
 	return false
 }
+
+// IsRISCV64CSR reports whether the op (as defined by a riscv.A*
+// constant) is one of the CSR instructions, which take a CSR number
+// as their second operand.
+func IsRISCV64CSR(op obj.As) bool {
+	switch op {
+	case riscv.ACSRRW, riscv.ACSRRS, riscv.ACSRRC, riscv.ACSRRWI, riscv.ACSRRSI, riscv.ACSRRCI:
+		return true
+	}
+	return false
+}
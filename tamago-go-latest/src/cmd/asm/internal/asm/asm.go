@@ -771,6 +771,18 @@ func (p *Parser) asmInstruction(op obj.As, cond string, a []obj.Addr) {
 				prog.RegTo2 = a[2].Reg
 				break
 			}
+			// RISCV64 CSR instructions take the CSR number as a constant
+			// second operand.
+			if arch.IsRISCV64CSR(op) {
+				if a[1].Type != obj.TYPE_CONST {
+					p.errorf("invalid addressing modes for second operand to %s instruction, must be constant", op)
+					return
+				}
+				prog.From = a[0]
+				prog.AddRestSource(a[1])
+				prog.To = a[2]
+				break
+			}
 			prog.From = a[0]
 			prog.Reg = p.getRegister(prog, op, &a[1])
 			prog.To = a[2]
@@ -480,9 +480,17 @@ func TestPPC64EndToEnd(t *testing.T) {
 }
 
 func TestRISCVEndToEnd(t *testing.T) {
+	defer func(old bool) { buildcfg.GORISCV64RVC = old }(buildcfg.GORISCV64RVC)
+	buildcfg.GORISCV64RVC = false
 	testEndToEnd(t, "riscv64", "riscv64")
 }
 
+func TestRISCVCompressedEndToEnd(t *testing.T) {
+	defer func(old bool) { buildcfg.GORISCV64RVC = old }(buildcfg.GORISCV64RVC)
+	buildcfg.GORISCV64RVC = true
+	testEndToEnd(t, "riscv64", "riscv64rvc")
+}
+
 func TestRISCVErrors(t *testing.T) {
 	testErrors(t, "riscv64", "riscv64error")
 }
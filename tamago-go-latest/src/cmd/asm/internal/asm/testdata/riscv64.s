@@ -172,6 +172,16 @@ start:
 	SD	X5, (X6)				// 23305300
 	SD	X5, 4(X6)				// 23325300
 
+	// 7.1: CSR Instructions (Zicsr)
+	CSRRW	X5, $832, X6				// 73930234
+	CSRRS	X5, $3072, X6				// 73a302c0
+	CSRRS	X10, $2048, X0				// 73200580
+	CSRRC	X5, $768, X6				// 73b30230
+	CSRRW	$5, $832, X6				// 73d30234
+	CSRRWI	$5, $832, X6				// 73d30234
+	CSRRSI	$31, $4095, X6				// 73e3ffff
+	CSRRCI	$0, $768, X6				// 73730030
+
 	// 8.1: Base Counters and Timers (Zicntr)
 	RDCYCLE		X5				// f32200c0
 	RDTIME		X5				// f32210c0
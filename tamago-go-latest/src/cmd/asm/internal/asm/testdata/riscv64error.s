@@ -46,4 +46,9 @@ TEXT errors(SB),$0
 	SRLI	$1, X5, F1			// ERROR "expected integer register in rd position but got non-integer register F1"
 	SRLI	$1, F1, X5			// ERROR "expected integer register in rs1 position but got non-integer register F1"
 	FNES	F1, (X5)			// ERROR "needs an integer register output"
+	CSRRS	X5, $4096, X6			// ERROR "CSR 0x1000 must be in range"
+	CSRRS	X5, $-1, X6			// ERROR "CSR -0x1 must be in range"
+	CSRRS	F1, $768, X6			// ERROR "expected integer register in rs1 position"
+	CSRRWI	$32, $768, X6			// ERROR "immediate out of range 0 to 31"
+	CSRRSI	$-1, $768, X6			// ERROR "immediate out of range 0 to 31"
 	RET
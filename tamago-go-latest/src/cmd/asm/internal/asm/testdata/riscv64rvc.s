@@ -0,0 +1,74 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "../../../../../runtime/textflag.h"
+
+// Compressed encodings emitted with GORISCV64=...,rvc.
+
+TEXT asmtest(SB),DUPOK|NOSPLIT|NOFRAME,$0
+	// Integer computational instructions
+	ADDI	$0, X0, X0				// 0100
+	ADDI	$-32, X0, X5				// 8152
+	MOV	$-32, X5				// 8152
+	ADDI	$0, X6, X5				// 9a82
+	MOV	X6, X5					// 9a82
+	ADDI	$31, X5					// fd02
+	ADDI	$496, SP				// 7d61
+	ADDI	$-512, SP				// 0171
+	ADDI	$1020, SP, X8				// e01f
+	ADDIW	$-1, X5					// fd32
+	LUI	$31, X5					// fd62
+	LUI	$-1, X5					// fd72
+	SLLI	$63, X5					// fe12
+	SRLI	$1, X8					// 0580
+	SRAI	$32, X15				// 8197
+	ANDI	$-32, X9				// 8198
+	ADD	X6, X5					// 9a92
+	ADD	X5, X6, X5				// 9a92
+	ADD	X6, X0, X5				// 9a82
+	SUB	X9, X8					// 058c
+	XOR	X9, X8					// 258c
+	OR	X9, X8					// 458c
+	AND	X9, X8					// 658c
+	AND	X8, X9, X8				// 658c
+	SUBW	X9, X8					// 059c
+	ADDW	X9, X8					// 259c
+
+	// Loads and stores
+	MOV	248(X8), X9				// 647c
+	MOVW	124(X8), X9				// 645c
+	MOV	504(SP), X5				// fe72
+	MOVW	252(SP), X5				// fe52
+	MOV	X9, 248(X8)				// 64fc
+	MOVW	X9, 124(X8)				// 64dc
+	MOV	X5, 504(SP)				// 96ff
+	MOVW	X5, 252(SP)				// 96df
+	MOVD	248(X8), F9				// 643c
+	MOVD	F9, 248(X8)				// 64bc
+	MOVD	504(SP), F5				// fe32
+	MOVD	F5, 504(SP)				// 96bf
+
+	// Control transfer instructions
+	JMP	(X5)					// 8282
+	JALR	X1, (X5)				// 8292
+	BEQZ	X8, 2(PC)				// 11c0
+	BNEZ	X9, -1(PC)				// fdfc
+	JMP	-1(PC)					// fdbf
+	BEQZ	X5, 2(PC)				// 63830200
+	EBREAK						// 0290
+
+	// No compressed encoding for these operands
+	ADDI	$32, X5					// 93820202
+	MOV	256(X8), X9				// 83340410
+	SUB	X9, X8, X5				// b3029440
+	ADD	X6, X5, X7				// b3836200
+	JALR	X6, (X5)				// 67830200
+
+	// Instructions with relocations keep their full length
+	MOV	tls(SB), X5				// b70f00009b8f0f00b38f4f0083b20f00
+	MOV	X5, tls(SB)				// b70f00009b8f0f00b38f4f0023b05f00
+
+	RET
+
+GLOBL tls(SB), TLSBSS, $8
@@ -81,7 +81,7 @@ func main() {
 
 	// Set macros for GOEXPERIMENTs so we can easily switch
 	// runtime assembly code based on them.
-	if objabi.LookupPkgSpecial(ctxt.Pkgpath).AllowAsmABI {
+	if pkg := objabi.LookupPkgSpecial(ctxt.Pkgpath); pkg.AllowAsmABI || pkg.Runtime {
 		for _, exp := range buildcfg.Experiment.Enabled() {
 			flags.D = append(flags.D, "GOEXPERIMENT_"+exp)
 		}
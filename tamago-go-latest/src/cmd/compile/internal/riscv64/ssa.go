@@ -14,6 +14,7 @@ import (
 	"cmd/compile/internal/types"
 	"cmd/internal/obj"
 	"cmd/internal/obj/riscv"
+	"internal/buildcfg"
 )
 
 // ssaRegToReg maps ssa register numbers to obj register numbers.
@@ -180,6 +181,17 @@ func largestMove(alignment int64) (obj.As, int64) {
 	}
 }
 
+// duffOffset converts an offset into duffzero or duffcopy, computed by the
+// rules for blocks of size bytes, to the offset into the assembled code.
+// With compressed instructions the ADDs of each block take 2 bytes rather
+// than 4, while the loads and stores through X24 and X25 remain 4 bytes.
+func duffOffset(off, size int64) int64 {
+	if !buildcfg.GORISCV64RVC {
+		return off
+	}
+	return off / size * (size * 3 / 4)
+}
+
 // ssaMarkMoves marks any MOVXconst ops that need to avoid clobbering flags.
 // RISC-V has no flags, so this is a no-op.
 func ssaMarkMoves(s *ssagen.State, b *ssa.Block) {}
@@ -759,14 +771,14 @@ func ssaGenValue(s *ssagen.State, v *ssa.Value) {
 		p.To.Type = obj.TYPE_MEM
 		p.To.Name = obj.NAME_EXTERN
 		p.To.Sym = ir.Syms.Duffzero
-		p.To.Offset = v.AuxInt
+		p.To.Offset = duffOffset(v.AuxInt, 8)
 
 	case ssa.OpRISCV64DUFFCOPY:
 		p := s.Prog(obj.ADUFFCOPY)
 		p.To.Type = obj.TYPE_MEM
 		p.To.Name = obj.NAME_EXTERN
 		p.To.Sym = ir.Syms.Duffcopy
-		p.To.Offset = v.AuxInt
+		p.To.Offset = duffOffset(v.AuxInt, 16)
 
 	case ssa.OpRISCV64LoweredPubBarrier:
 		// FENCE
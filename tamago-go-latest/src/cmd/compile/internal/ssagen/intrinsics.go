@@ -40,6 +40,8 @@ type intrinsicBuildConfig struct {
 	gomips64  string
 	goppc64   int
 	goriscv64 int
+
+	singleThreadAtomics bool
 }
 
 type intrinsicBuilders map[intrinsicKey]intrinsicBuilder
@@ -102,6 +104,8 @@ func initIntrinsics(cfg *intrinsicBuildConfig) {
 			gomips64:      buildcfg.GOMIPS64,
 			goppc64:       buildcfg.GOPPC64,
 			goriscv64:     buildcfg.GORISCV64,
+
+			singleThreadAtomics: buildcfg.Experiment.SingleThreadAtomics,
 		}
 	}
 	intrinsics = intrinsicBuilders{}
@@ -668,6 +672,10 @@ func initIntrinsics(cfg *intrinsicBuildConfig) {
 		},
 		sys.AMD64, sys.Loong64)
 
+	if cfg.singleThreadAtomics {
+		initSingleThreadAtomicIntrinsics()
+	}
+
 	// Aliases for atomic load operations
 	alias("internal/runtime/atomic", "Loadint32", "internal/runtime/atomic", "Load", all...)
 	alias("internal/runtime/atomic", "Loadint64", "internal/runtime/atomic", "Load64", all...)
@@ -716,8 +724,8 @@ func initIntrinsics(cfg *intrinsicBuildConfig) {
 	alias("internal/runtime/atomic", "CasRel", "internal/runtime/atomic", "Cas", lwatomics...)
 
 	// Aliases for atomic And/Or operations
-	alias("internal/runtime/atomic", "Anduintptr", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchLoong64)
-	alias("internal/runtime/atomic", "Oruintptr", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchLoong64)
+	alias("internal/runtime/atomic", "Anduintptr", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("internal/runtime/atomic", "Oruintptr", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchLoong64, sys.ArchRISCV64)
 
 	/******** math ********/
 	addF("math", "sqrt",
@@ -1247,16 +1255,16 @@ func initIntrinsics(cfg *intrinsicBuildConfig) {
 	alias("sync/atomic", "AddUintptr", "internal/runtime/atomic", "Xadd", p4...)
 	alias("sync/atomic", "AddUintptr", "internal/runtime/atomic", "Xadd64", p8...)
 
-	alias("sync/atomic", "AndInt32", "internal/runtime/atomic", "And32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "AndUint32", "internal/runtime/atomic", "And32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "AndInt64", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "AndUint64", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "AndUintptr", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "OrInt32", "internal/runtime/atomic", "Or32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "OrUint32", "internal/runtime/atomic", "Or32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "OrInt64", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "OrUint64", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
-	alias("sync/atomic", "OrUintptr", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64)
+	alias("sync/atomic", "AndInt32", "internal/runtime/atomic", "And32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "AndUint32", "internal/runtime/atomic", "And32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "AndInt64", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "AndUint64", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "AndUintptr", "internal/runtime/atomic", "And64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "OrInt32", "internal/runtime/atomic", "Or32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "OrUint32", "internal/runtime/atomic", "Or32", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "OrInt64", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "OrUint64", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
+	alias("sync/atomic", "OrUintptr", "internal/runtime/atomic", "Or64", sys.ArchARM64, sys.ArchAMD64, sys.ArchLoong64, sys.ArchRISCV64)
 
 	/******** math/big ********/
 	alias("math/big", "mulWW", "math/bits", "Mul64", p8...)
@@ -1553,6 +1561,105 @@ func initIntrinsics(cfg *intrinsicBuildConfig) {
 		sys.AMD64)
 }
 
+// initSingleThreadAtomicIntrinsics replaces the riscv64 atomic intrinsics with
+// plain loads and stores, for GOEXPERIMENT=singlethreadatomics (tamago/riscv64
+// only). On a single hart, with no preemption, every memory access is atomic
+// and ordered, LR/SC loops, AMOs and fences are therefore pure overhead (and
+// expensive to prove on zkVMs).
+func initSingleThreadAtomicIntrinsics() {
+	for k := range intrinsics {
+		if k.arch.Family == sys.RISCV64 && (k.pkg == "internal/runtime/atomic" || k.fn == "publicationBarrier") {
+			delete(intrinsics, k)
+		}
+	}
+
+	add := func(pkg, fn string, b intrinsicBuilder) {
+		intrinsics.addForFamilies(pkg, fn, b, sys.RISCV64)
+	}
+
+	load := func(kind types.Kind) intrinsicBuilder {
+		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			return s.rawLoad(types.Types[kind], args[0])
+		}
+	}
+	// store never emits a write barrier as kind is not a pointer type
+	store := func(kind types.Kind) intrinsicBuilder {
+		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			s.store(types.Types[kind], args[0], args[1])
+			return nil
+		}
+	}
+	// update stores op(*ptr, val), returning the old value if ret, the new
+	// one otherwise.
+	update := func(op ssa.Op, kind types.Kind, ret bool) intrinsicBuilder {
+		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			typ := types.Types[kind]
+			old := s.rawLoad(typ, args[0])
+			v := args[1]
+			if op != ssa.OpCopy {
+				v = s.newValue2(op, typ, old, v)
+			}
+			s.store(typ, args[0], v)
+			if ret {
+				return old
+			}
+			return v
+		}
+	}
+	cas := func(op ssa.Op, kind types.Kind) intrinsicBuilder {
+		return func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			typ := types.Types[kind]
+			eq := s.newValue2(op, types.Types[types.TBOOL], s.rawLoad(typ, args[0]), args[1])
+			b := s.endBlock()
+			b.Kind = ssa.BlockIf
+			b.SetControl(eq)
+			bStore := s.f.NewBlock(ssa.BlockPlain)
+			bEnd := s.f.NewBlock(ssa.BlockPlain)
+			b.AddEdgeTo(bStore)
+			b.AddEdgeTo(bEnd)
+
+			s.startBlock(bStore)
+			s.store(typ, args[0], args[2])
+			s.endBlock().AddEdgeTo(bEnd)
+
+			s.startBlock(bEnd)
+			return eq
+		}
+	}
+
+	add("runtime", "publicationBarrier",
+		func(s *state, n *ir.CallExpr, args []*ssa.Value) *ssa.Value {
+			return nil
+		})
+
+	add("internal/runtime/atomic", "Load", load(types.TUINT32))
+	add("internal/runtime/atomic", "Load8", load(types.TUINT8))
+	add("internal/runtime/atomic", "Load64", load(types.TUINT64))
+	add("internal/runtime/atomic", "Loadp", load(types.TUNSAFEPTR))
+
+	add("internal/runtime/atomic", "Store", store(types.TUINT32))
+	add("internal/runtime/atomic", "Store8", store(types.TUINT8))
+	add("internal/runtime/atomic", "Store64", store(types.TUINT64))
+	add("internal/runtime/atomic", "StorepNoWB", store(types.TUINTPTR))
+
+	add("internal/runtime/atomic", "Xchg", update(ssa.OpCopy, types.TUINT32, true))
+	add("internal/runtime/atomic", "Xchg64", update(ssa.OpCopy, types.TUINT64, true))
+	add("internal/runtime/atomic", "Xadd", update(ssa.OpAdd32, types.TUINT32, false))
+	add("internal/runtime/atomic", "Xadd64", update(ssa.OpAdd64, types.TUINT64, false))
+
+	add("internal/runtime/atomic", "Cas", cas(ssa.OpEq32, types.TUINT32))
+	add("internal/runtime/atomic", "Cas64", cas(ssa.OpEq64, types.TUINT64))
+
+	add("internal/runtime/atomic", "And8", update(ssa.OpAnd8, types.TUINT8, true))
+	add("internal/runtime/atomic", "And", update(ssa.OpAnd32, types.TUINT32, true))
+	add("internal/runtime/atomic", "And32", update(ssa.OpAnd32, types.TUINT32, true))
+	add("internal/runtime/atomic", "And64", update(ssa.OpAnd64, types.TUINT64, true))
+	add("internal/runtime/atomic", "Or8", update(ssa.OpOr8, types.TUINT8, true))
+	add("internal/runtime/atomic", "Or", update(ssa.OpOr32, types.TUINT32, true))
+	add("internal/runtime/atomic", "Or32", update(ssa.OpOr32, types.TUINT32, true))
+	add("internal/runtime/atomic", "Or64", update(ssa.OpOr64, types.TUINT64, true))
+}
+
 // findIntrinsic returns a function which builds the SSA equivalent of the
 // function identified by the symbol sym.  If sym is not an intrinsic call, returns nil.
 func findIntrinsic(sym *types.Sym) intrinsicBuilder {
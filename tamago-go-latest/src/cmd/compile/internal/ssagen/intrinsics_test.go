@@ -1386,4 +1386,26 @@ func TestIntrinsicBuilders(t *testing.T) {
 	if intrinsics.lookup(sys.ArchPPC64, "internal/runtime/sys", "Bswap64") == nil {
 		t.Errorf("No intrinsic for internal/runtime/sys.Bswap64 on arch %v", sys.ArchPPC64)
 	}
+
+	if intrinsics.lookup(sys.ArchRISCV64, "sync/atomic", "OrUint32") != nil {
+		t.Errorf("Found intrinsic for sync/atomic.OrUint32 on arch %v", sys.ArchRISCV64)
+	}
+
+	cfg.singleThreadAtomics = true
+
+	initIntrinsics(cfg)
+
+	for _, fn := range []string{"Cas64", "Xadd", "Xchg64", "And32", "Or64", "Anduintptr", "Casp1"} {
+		if intrinsics.lookup(sys.ArchRISCV64, "internal/runtime/atomic", fn) == nil {
+			t.Errorf("No intrinsic for internal/runtime/atomic.%s on arch %v", fn, sys.ArchRISCV64)
+		}
+	}
+
+	if intrinsics.lookup(sys.ArchRISCV64, "sync/atomic", "OrUint32") == nil {
+		t.Errorf("No intrinsic for sync/atomic.OrUint32 on arch %v", sys.ArchRISCV64)
+	}
+
+	if intrinsics.lookup(sys.ArchRISCV64, "runtime", "publicationBarrier") == nil {
+		t.Errorf("No intrinsic for runtime.publicationBarrier on arch %v", sys.ArchRISCV64)
+	}
 }
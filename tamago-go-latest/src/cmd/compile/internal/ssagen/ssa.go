@@ -1385,6 +1385,20 @@ func (s *state) rawLoad(t *types.Type, src *ssa.Value) *ssa.Value {
 	return s.newValue2(ssa.OpLoad, t, src, s.mem())
 }
 
+// loadCachePtr loads the type switch or type assertion cache pointer out of
+// descriptor d, with an atomic load so we ensure that we see a fully written
+// cache. Under GOEXPERIMENT=singlethreadatomics a plain load is atomic, as
+// for the atomic intrinsics (see initSingleThreadAtomicIntrinsics).
+func (s *state) loadCachePtr(d *ssa.Value) *ssa.Value {
+	typs := s.f.Config.Types
+	if buildcfg.Experiment.SingleThreadAtomics {
+		return s.rawLoad(typs.BytePtr, d)
+	}
+	atomicLoad := s.newValue2(ssa.OpAtomicLoadPtr, types.NewTuple(typs.BytePtr, types.TypeMem), d, s.mem())
+	s.vars[memVar] = s.newValue1(ssa.OpSelect1, types.TypeMem, atomicLoad)
+	return s.newValue1(ssa.OpSelect0, typs.BytePtr, atomicLoad)
+}
+
 func (s *state) store(t *types.Type, dst, val *ssa.Value) {
 	s.vars[memVar] = s.newValue3A(ssa.OpStore, types.TypeMem, t, dst, val, s.mem())
 }
@@ -2059,11 +2073,8 @@ func (s *state) stmt(n ir.Node) {
 				zext = ssa.OpZeroExt32to64
 			}
 
-			// Load cache pointer out of descriptor, with an atomic load so
-			// we ensure that we see a fully written cache.
-			atomicLoad := s.newValue2(ssa.OpAtomicLoadPtr, types.NewTuple(typs.BytePtr, types.TypeMem), d, s.mem())
-			cache := s.newValue1(ssa.OpSelect0, typs.BytePtr, atomicLoad)
-			s.vars[memVar] = s.newValue1(ssa.OpSelect1, types.TypeMem, atomicLoad)
+			// Load cache pointer out of descriptor.
+			cache := s.loadCachePtr(d)
 
 			// Initialize hash variable.
 			s.vars[hashVar] = s.newValue1(zext, typs.Uintptr, h)
@@ -5793,11 +5804,8 @@ func (s *state) dottype1(pos src.XPos, src, dst *types.Type, iface, source, targ
 				cacheHit := s.f.NewBlock(ssa.BlockPlain)
 				cacheMiss := s.f.NewBlock(ssa.BlockPlain)
 
-				// Load cache pointer out of descriptor, with an atomic load so
-				// we ensure that we see a fully written cache.
-				atomicLoad := s.newValue2(ssa.OpAtomicLoadPtr, types.NewTuple(typs.BytePtr, types.TypeMem), d, s.mem())
-				cache := s.newValue1(ssa.OpSelect0, typs.BytePtr, atomicLoad)
-				s.vars[memVar] = s.newValue1(ssa.OpSelect1, types.TypeMem, atomicLoad)
+				// Load cache pointer out of descriptor.
+				cache := s.loadCachePtr(d)
 
 				// Load hash from type or itab.
 				var hash *ssa.Value
@@ -0,0 +1,117 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"internal/testenv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const atomicsSrc = `
+package p
+
+import "sync/atomic"
+
+var x32 uint32
+var x64 uint64
+
+func f() bool {
+	atomic.AddUint32(&x32, 1)
+	atomic.SwapUint64(&x64, uint64(atomic.LoadUint32(&x32)))
+	atomic.OrUint32(&x32, 2)
+	return atomic.CompareAndSwapUint64(&x64, 1, 2)
+}
+
+type I interface{ M() }
+type J interface{ N() }
+
+// The type switch and type assertion to interface types load their cache
+// pointer atomically.
+func g(x any) int {
+	switch x.(type) {
+	case I:
+		return 1
+	case J:
+		return 2
+	}
+	return 0
+}
+
+func h(x any) I {
+	return x.(I)
+}
+`
+
+// TestSingleThreadAtomics checks that GOEXPERIMENT=singlethreadatomics lowers
+// tamago/riscv64 atomic operations, either explicit or emitted by the
+// compiler, to plain loads and stores, with fewer instructions.
+func TestSingleThreadAtomics(t *testing.T) {
+	if testing.Short() {
+		// This test builds the runtime for tamago/riscv64 twice, which
+		// takes a while.
+		t.Skip("skip in short mode")
+	}
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "x.go")
+	err := os.WriteFile(src, []byte(atomicsSrc), 0644)
+	if err != nil {
+		t.Fatalf("write file failed: %v", err)
+	}
+
+	atomic := regexp.MustCompile(`\t(LR[WD]|SC[WD]|AMO[A-Z]+|FENCE)\t`)
+
+	// compile returns the number of instructions of f, and how many are
+	// atomic in each function.
+	compile := func(experiment string) (n int, atomics map[string]int) {
+		cmd := testenv.Command(t, testenv.GoToolPath(t), "build", "-gcflags=-S", "-o", os.DevNull, src)
+		cmd.Env = append(os.Environ(), "GOOS=tamago", "GOARCH=riscv64", "GOEXPERIMENT="+experiment)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("go build failed: %v\n%s", err, out)
+		}
+
+		atomics = make(map[string]int)
+		fn := ""
+		for _, line := range strings.Split(string(out), "\n") {
+			if strings.Contains(line, " STEXT ") {
+				fn, _, _ = strings.Cut(line, " ")
+				continue
+			}
+			if !strings.HasPrefix(line, "\t0x") {
+				continue
+			}
+			if fn == "command-line-arguments.f" {
+				n++
+			}
+			if atomic.MatchString(line) {
+				atomics[fn]++
+			}
+		}
+		return n, atomics
+	}
+
+	n, atomics := compile("")
+	for _, fn := range []string{"f", "g", "h"} {
+		if atomics["command-line-arguments."+fn] == 0 {
+			t.Fatalf("no atomic instructions found in %s without the experiment", fn)
+		}
+	}
+
+	plainN, plainAtomics := compile("singlethreadatomics")
+	t.Logf("%d instructions (%d atomic) in f, %d with GOEXPERIMENT=singlethreadatomics", n, atomics["command-line-arguments.f"], plainN)
+	for fn, n := range plainAtomics {
+		t.Errorf("found %d atomic instructions in %s with GOEXPERIMENT=singlethreadatomics", n, fn)
+	}
+	if plainN >= n {
+		t.Errorf("GOEXPERIMENT=singlethreadatomics emitted %d instructions, want less than %d", plainN, n)
+	}
+}
@@ -943,8 +943,13 @@ func runInstall(pkg string, ch chan struct{}) {
 		}
 	}
 	if goarch == "riscv64" {
-		// Define GORISCV64_value from goriscv64
-		asmArgs = append(asmArgs, "-D", "GORISCV64_"+goriscv64)
+		// Define GORISCV64_value from goriscv64, and GORISCV64_rvc
+		// when compressed instructions are enabled.
+		profile, rvc := strings.CutSuffix(goriscv64, ",rvc")
+		asmArgs = append(asmArgs, "-D", "GORISCV64_"+profile)
+		if rvc {
+			asmArgs = append(asmArgs, "-D", "GORISCV64_rvc")
+		}
 	}
 	if goarch == "arm" {
 		// Define GOARM_value from goarm, which can be either a version
@@ -646,7 +646,9 @@ Architecture-specific environment variables:
 		Valid values are power8 (default), power9, power10.
 	GORISCV64
 		For GOARCH=riscv64, the RISC-V user-mode application profile for which
-		to compile. Valid values are rva20u64 (default), rva22u64.
+		to compile. Valid values are rva20u64 (default), rva22u64 and rv64ima
+		(RV64IMA without floating point, which implies softfloat). The value may
+		optionally end in ",rvc" to emit compressed instructions where possible.
 		See https://github.com/riscv/riscv-profiles/blob/main/src/profiles.adoc
 	GOWASM
 		For GOARCH=wasm, comma-separated list of experimental WebAssembly features to use.
@@ -953,7 +955,9 @@ The defined architecture feature build tags are:
 	  feature build tags.
 	- For GOARCH=riscv64,
 	  GORISCV64=rva20u64 and rva22u64 correspond to the riscv64.rva20u64
-	  and riscv64.rva22u64 build tags.
+	  and riscv64.rva22u64 build tags, GORISCV64=rv64ima sets only the
+	  riscv64.rv64ima build tag. The ",rvc" suffix sets the riscv64.rvc
+	  build tag.
 	- For GOARCH=wasm, GOWASM=satconv and signext
 	  correspond to the wasm.satconv and wasm.signext feature build tags.
 
@@ -359,8 +359,13 @@ func asmArgs(a *Action, p *load.Package) []any {
 	}
 
 	if cfg.Goarch == "riscv64" {
-		// Define GORISCV64_value from cfg.GORISCV64.
-		args = append(args, "-D", "GORISCV64_"+cfg.GORISCV64)
+		// Define GORISCV64_value from cfg.GORISCV64, and GORISCV64_rvc
+		// when compressed instructions are enabled.
+		profile, rvc := strings.CutSuffix(cfg.GORISCV64, ",rvc")
+		args = append(args, "-D", "GORISCV64_"+profile)
+		if rvc {
+			args = append(args, "-D", "GORISCV64_rvc")
+		}
 	}
 
 	if cfg.Goarch == "arm" {
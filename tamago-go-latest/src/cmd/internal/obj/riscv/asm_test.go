@@ -200,6 +200,31 @@ func TestNoRet(t *testing.T) {
 	}
 }
 
+func TestFloatRV64IMA(t *testing.T) {
+	dir := t.TempDir()
+	tmpfile := filepath.Join(dir, "x.s")
+	asm := `
+TEXT _stub(SB),$0-0
+	ADD	X5, X6, X7
+	MOVD	F0, 8(X2)
+	FADDD	F1, F2, F3
+	MOVF	F4, F5
+	RET
+`
+	if err := os.WriteFile(tmpfile, []byte(asm), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := testenv.Command(t, testenv.GoToolPath(t), "tool", "asm", "-o", filepath.Join(dir, "x.o"), tmpfile)
+	cmd.Env = append(os.Environ(), "GOARCH=riscv64", "GOOS=tamago", "GORISCV64=rv64ima")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("assembly of floating point instructions succeeded with GORISCV64=rv64ima")
+	}
+	if n := strings.Count(string(out), "not supported with GORISCV64=rv64ima"); n != 3 {
+		t.Errorf("got %d floating point errors, want 3\n%s", n, out)
+	}
+}
+
 func TestImmediateSplitting(t *testing.T) {
 	dir := t.TempDir()
 	tmpfile := filepath.Join(dir, "x.s")
@@ -293,3 +318,52 @@ TEXT _stub(SB),$0-0
 		t.Errorf("PCALIGN test failed - got %s\nwant %s", out, want)
 	}
 }
+
+// TestCompressed checks that GORISCV64=...,rvc compresses branches only when
+// they are in range and pads aligned code with compressed NOPs.
+func TestCompressed(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+
+	dir := t.TempDir()
+	tmpfile := filepath.Join(dir, "x.s")
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "TEXT _branch(SB),$0-0")
+	fmt.Fprintln(&buf, "\tBEQZ\tX8, near")
+	fmt.Fprintln(&buf, "\tBEQZ\tX8, far")
+	fmt.Fprintln(&buf, "near:")
+	for i := 0; i < 200; i++ {
+		fmt.Fprintln(&buf, "\tADD\t$0, X0, X0")
+	}
+	fmt.Fprintln(&buf, "far:")
+	fmt.Fprintln(&buf, "\tRET")
+	fmt.Fprintln(&buf, "TEXT _align(SB),$0-0")
+	fmt.Fprintln(&buf, "\tFENCE")
+	fmt.Fprintln(&buf, "\tADD\t$0, X0, X0")
+	fmt.Fprintln(&buf, "\tPCALIGN\t$8")
+	fmt.Fprintln(&buf, "\tFENCE")
+	fmt.Fprintln(&buf, "\tRET")
+	if err := os.WriteFile(tmpfile, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := testenv.Command(t, testenv.GoToolPath(t), "tool", "asm", "-o", filepath.Join(dir, "x.o"), "-S", tmpfile)
+	cmd.Env = append(os.Environ(), "GOARCH=riscv64", "GOOS=linux", "GORISCV64=rva20u64,rvc")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Failed to assemble: %v\n%s", err, out)
+	}
+	// The near branch, NOPs and RET are compressed, the far branch is
+	// 404 bytes away which is out of range of C.BEQZ.
+	if !strings.Contains(string(out), "_branch STEXT asm size=408 ") {
+		t.Errorf("unexpected size of compressed branches:\n%s", out)
+	}
+	// The expected instruction sequence after alignment:
+	//	FENCE
+	//	C.NOP
+	//	C.NOP
+	//	FENCE
+	//	C.JR RA
+	want := "0f 00 f0 0f 01 00 01 00 0f 00 f0 0f 82 80"
+	if !strings.Contains(string(out), want) {
+		t.Errorf("PCALIGN with compressed instructions failed - got %s\nwant %s", out, want)
+	}
+}
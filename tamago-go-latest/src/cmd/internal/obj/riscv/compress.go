@@ -0,0 +1,361 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riscv
+
+import (
+	"cmd/internal/obj"
+	"internal/buildcfg"
+)
+
+// When GORISCV64 ends in ",rvc" instructions are replaced by their 16 bit
+// equivalent from the "C" Standard Extension for Compressed Instructions
+// (RVC), when one exists for their operands. This is done at the machine
+// instruction level, the assembler syntax and the instructions generated
+// from an *obj.Prog are unchanged.
+//
+// Instructions patched by relocations, or whose immediate is only resolved
+// after layout, are never compressed. Branches and jumps are assumed to be
+// compressible and are marked NO_COMPRESS by preprocess once found to be out
+// of range of the compressed encodings.
+
+// noCompressMarks are the Prog.Mark flags that keep 32 bit encodings.
+const noCompressMarks = NO_COMPRESS | NEED_JAL_RELOC | NEED_CALL_RELOC | NEED_PCREL_ITYPE_RELOC | NEED_PCREL_STYPE_RELOC
+
+// Ranges of the compressed branch (C.BEQZ, C.BNEZ) and jump (C.J) offsets.
+const (
+	maxCBranchOffset = 1 << 8
+	maxCJumpOffset   = 1 << 11
+)
+
+// compressBranch checks that the compressed form of the branch or jump p, if
+// any, reaches offset, otherwise it marks p to be assembled uncompressed. It
+// reports whether the length of p has changed.
+func compressBranch(p *obj.Prog, offset int64) bool {
+	if !buildcfg.GORISCV64RVC || p.Mark&NO_COMPRESS != 0 {
+		return false
+	}
+	max := int64(maxCBranchOffset)
+	if p.As == AJAL {
+		max = maxCJumpOffset
+	}
+	if -max <= offset && offset < max {
+		return false
+	}
+	inss := instructionsForProg(p)
+	if len(inss) != 1 {
+		return false
+	}
+	n := inss[0].length()
+	p.Mark |= NO_COMPRESS
+	return inss[0].length() != n
+}
+
+// isIntReg reports whether r is an integer register.
+func isIntReg(r uint32) bool {
+	return REG_X0 <= r && r <= REG_X31
+}
+
+// isFloatReg reports whether r is a floating point register.
+func isFloatReg(r uint32) bool {
+	return REG_F0 <= r && r <= REG_F31
+}
+
+// regC returns the 3 bit register number used by compressed encodings for
+// X8-X15 (or F8-F15, when base is REG_F0).
+func regC(r, base uint32) (uint32, bool) {
+	if r < base+8 || r > base+15 {
+		return 0, false
+	}
+	return r - base - 8, true
+}
+
+// immFits reports whether x is a signed integer of nbits bits.
+func immFits(x int64, nbits uint) bool {
+	return -(1<<(nbits-1)) <= x && x < 1<<(nbits-1)
+}
+
+// uimmFits reports whether x is a non-negative multiple of scale below max.
+func uimmFits(x, scale, max int64) bool {
+	return 0 <= x && x < max && x%scale == 0
+}
+
+// encodeCI encodes a compressed instruction with a 6 bit immediate.
+func encodeCI(funct3, rd uint32, imm int64, op uint32) uint16 {
+	u := uint32(imm)
+	return uint16(funct3<<13 | (u>>5&1)<<12 | rd<<7 | (u&0x1f)<<2 | op)
+}
+
+// encodeCR encodes a compressed register instruction.
+func encodeCR(funct4, rd, rs2 uint32) uint16 {
+	return uint16(funct4<<12 | rd<<7 | rs2<<2 | 0b10)
+}
+
+// encodeCA encodes a compressed arithmetic instruction, rd and rs2 are
+// compressed register numbers.
+func encodeCA(funct6, rd, funct2, rs2 uint32) uint16 {
+	return uint16(funct6<<10 | rd<<7 | funct2<<5 | rs2<<2 | 0b01)
+}
+
+// encodeCLD encodes a compressed doubleword load or store (C.LD, C.SD,
+// C.FLD and C.FSD), rs1 and rd are compressed register numbers.
+func encodeCLD(funct3, rs1, rd uint32, imm int64) uint16 {
+	u := uint32(imm)
+	return uint16(funct3<<13 | (u>>3&7)<<10 | rs1<<7 | (u>>6&3)<<5 | rd<<2)
+}
+
+// encodeCLW encodes a compressed word load or store (C.LW and C.SW), rs1 and
+// rd are compressed register numbers.
+func encodeCLW(funct3, rs1, rd uint32, imm int64) uint16 {
+	u := uint32(imm)
+	return uint16(funct3<<13 | (u>>3&7)<<10 | rs1<<7 | (u>>2&1)<<6 | (u>>6&1)<<5 | rd<<2)
+}
+
+// encodeCLDSP encodes a compressed stack pointer relative doubleword load
+// (C.LDSP and C.FLDSP).
+func encodeCLDSP(funct3, rd uint32, imm int64) uint16 {
+	u := uint32(imm)
+	return uint16(funct3<<13 | (u>>5&1)<<12 | rd<<7 | (u>>3&3)<<5 | (u>>6&7)<<2 | 0b10)
+}
+
+// encodeCSDSP encodes a compressed stack pointer relative doubleword store
+// (C.SDSP and C.FSDSP).
+func encodeCSDSP(funct3, rs2 uint32, imm int64) uint16 {
+	u := uint32(imm)
+	return uint16(funct3<<13 | (u>>3&7)<<10 | (u>>6&7)<<7 | rs2<<2 | 0b10)
+}
+
+// encodeCB encodes a compressed conditional branch (C.BEQZ and C.BNEZ), rs1
+// is a compressed register number.
+func encodeCB(funct3, rs1 uint32, imm int64) uint16 {
+	u := uint32(imm)
+	return uint16(funct3<<13 | (u>>8&1)<<12 | (u>>3&3)<<10 | rs1<<7 | (u>>6&3)<<5 | (u>>1&3)<<3 | (u>>5&1)<<2 | 0b01)
+}
+
+// encodeCJ encodes a compressed jump (C.J).
+func encodeCJ(imm int64) uint16 {
+	u := uint32(imm)
+	return uint16(0b101<<13 | (u>>11&1)<<12 | (u>>4&1)<<11 | (u>>8&3)<<9 | (u>>10&1)<<8 |
+		(u>>6&1)<<7 | (u>>7&1)<<6 | (u>>1&7)<<3 | (u>>5&1)<<2 | 0b01)
+}
+
+// compress returns the compressed encoding of ins, if there is one and
+// compressed instructions are enabled.
+func (ins *instruction) compress() (uint16, bool) {
+	if !buildcfg.GORISCV64RVC || ins.p != nil && ins.p.Mark&noCompressMarks != 0 {
+		return 0, false
+	}
+
+	imm := ins.imm
+	if ins.p != nil && ins.p.To.Type == obj.TYPE_BRANCH {
+		// The branch target is not resolved yet, preprocess checks
+		// the offset against the range of the compressed encodings.
+		imm = 0
+	}
+
+	rd, rs1, rs2 := ins.rd, ins.rs1, ins.rs2
+	rdC, rdIsC := regC(rd, REG_X0)
+	rs1C, rs1IsC := regC(rs1, REG_X0)
+	rs2C, rs2IsC := regC(rs2, REG_X0)
+
+	switch ins.as {
+	case AADDI:
+		if !isIntReg(rd) || !isIntReg(rs1) {
+			break
+		}
+		switch {
+		case rd == REG_ZERO && rs1 == REG_ZERO && imm == 0:
+			return 0x0001, true // C.NOP
+		case rd == REG_ZERO:
+		case rs1 == REG_ZERO && immFits(imm, 6):
+			return encodeCI(0b010, regI(rd), imm, 0b01), true // C.LI
+		case rs1 != REG_ZERO && imm == 0:
+			return encodeCR(0b1000, regI(rd), regI(rs1)), true // C.MV
+		case rd == rs1 && immFits(imm, 6):
+			return encodeCI(0b000, regI(rd), imm, 0b01), true // C.ADDI
+		case rd == REG_SP && rs1 == REG_SP && imm%16 == 0 && immFits(imm, 10):
+			u := uint32(imm)
+			return uint16(0b011<<13 | (u>>9&1)<<12 | 2<<7 | (u>>4&1)<<6 | (u>>6&1)<<5 | (u>>7&3)<<3 | (u>>5&1)<<2 | 0b01), true // C.ADDI16SP
+		case rdIsC && rs1 == REG_SP && imm != 0 && uimmFits(imm, 4, 1<<10):
+			u := uint32(imm)
+			return uint16((u>>4&3)<<11 | (u>>6&0xf)<<7 | (u>>2&1)<<6 | (u>>3&1)<<5 | rdC<<2), true // C.ADDI4SPN
+		}
+
+	case AADDIW:
+		if isIntReg(rd) && rd != REG_ZERO && rd == rs1 && immFits(imm, 6) {
+			return encodeCI(0b001, regI(rd), imm, 0b01), true // C.ADDIW
+		}
+
+	case ALUI:
+		if isIntReg(rd) && rd != REG_ZERO && rd != REG_SP && imm != 0 && immFits(imm, 6) {
+			return encodeCI(0b011, regI(rd), imm, 0b01), true // C.LUI
+		}
+
+	case ASLLI:
+		if isIntReg(rd) && rd != REG_ZERO && rd == rs1 && imm > 0 && imm < 64 {
+			return encodeCI(0b000, regI(rd), imm, 0b10), true // C.SLLI
+		}
+
+	case ASRLI, ASRAI:
+		if rdIsC && rd == rs1 && imm > 0 && imm < 64 {
+			funct2 := uint32(0b00)
+			if ins.as == ASRAI {
+				funct2 = 0b01
+			}
+			u := uint32(imm)
+			return uint16(0b100<<13 | (u>>5&1)<<12 | funct2<<10 | rdC<<7 | (u&0x1f)<<2 | 0b01), true // C.SRLI, C.SRAI
+		}
+
+	case AANDI:
+		if rdIsC && rd == rs1 && immFits(imm, 6) {
+			u := uint32(imm)
+			return uint16(0b100<<13 | (u>>5&1)<<12 | 0b10<<10 | rdC<<7 | (u&0x1f)<<2 | 0b01), true // C.ANDI
+		}
+
+	case AADD:
+		if !isIntReg(rd) || !isIntReg(rs1) || !isIntReg(rs2) || rd == REG_ZERO {
+			break
+		}
+		switch {
+		case rs1 == REG_ZERO && rs2 != REG_ZERO:
+			return encodeCR(0b1000, regI(rd), regI(rs2)), true // C.MV
+		case rs2 == REG_ZERO && rs1 != REG_ZERO:
+			return encodeCR(0b1000, regI(rd), regI(rs1)), true // C.MV
+		case rd == rs1 && rs2 != REG_ZERO:
+			return encodeCR(0b1001, regI(rd), regI(rs2)), true // C.ADD
+		case rd == rs2 && rs1 != REG_ZERO:
+			return encodeCR(0b1001, regI(rd), regI(rs1)), true // C.ADD
+		}
+
+	case ASUB, AXOR, AOR, AAND, ASUBW, AADDW:
+		if !rdIsC || !rs1IsC || !rs2IsC {
+			break
+		}
+		funct6, funct2 := uint32(0b100011), uint32(0)
+		switch ins.as {
+		case ASUB:
+			funct2 = 0b00
+		case AXOR:
+			funct2 = 0b01
+		case AOR:
+			funct2 = 0b10
+		case AAND:
+			funct2 = 0b11
+		case ASUBW:
+			funct6, funct2 = 0b100111, 0b00
+		case AADDW:
+			funct6, funct2 = 0b100111, 0b01
+		}
+		switch {
+		case rd == rs1:
+			return encodeCA(funct6, rdC, funct2, rs2C), true
+		case rd == rs2 && ins.as != ASUB && ins.as != ASUBW:
+			return encodeCA(funct6, rdC, funct2, rs1C), true
+		}
+
+	case ALD, ALW:
+		if !isIntReg(rd) || !isIntReg(rs1) {
+			break
+		}
+		scale, funct3 := int64(8), uint32(0b011)
+		if ins.as == ALW {
+			scale, funct3 = 4, 0b010
+		}
+		switch {
+		case rdIsC && rs1IsC && uimmFits(imm, scale, 32*scale):
+			if ins.as == ALW {
+				return encodeCLW(funct3, rs1C, rdC, imm), true // C.LW
+			}
+			return encodeCLD(funct3, rs1C, rdC, imm), true // C.LD
+		case rd != REG_ZERO && rs1 == REG_SP && uimmFits(imm, scale, 64*scale):
+			if ins.as == ALW {
+				u := uint32(imm)
+				return uint16(funct3<<13 | (u>>5&1)<<12 | regI(rd)<<7 | (u>>2&7)<<4 | (u>>6&3)<<2 | 0b10), true // C.LWSP
+			}
+			return encodeCLDSP(funct3, regI(rd), imm), true // C.LDSP
+		}
+
+	case ASD, ASW:
+		// For stores rd is the base register and rs1 the source.
+		if !isIntReg(rd) || !isIntReg(rs1) {
+			break
+		}
+		scale, funct3 := int64(8), uint32(0b111)
+		if ins.as == ASW {
+			scale, funct3 = 4, 0b110
+		}
+		switch {
+		case rdIsC && rs1IsC && uimmFits(imm, scale, 32*scale):
+			if ins.as == ASW {
+				return encodeCLW(funct3, rdC, rs1C, imm), true // C.SW
+			}
+			return encodeCLD(funct3, rdC, rs1C, imm), true // C.SD
+		case rd == REG_SP && uimmFits(imm, scale, 64*scale):
+			if ins.as == ASW {
+				u := uint32(imm)
+				return uint16(funct3<<13 | (u>>2&0xf)<<9 | (u>>6&3)<<7 | regI(rs1)<<2 | 0b10), true // C.SWSP
+			}
+			return encodeCSDSP(funct3, regI(rs1), imm), true // C.SDSP
+		}
+
+	case AFLD:
+		if !isFloatReg(rd) || !isIntReg(rs1) {
+			break
+		}
+		switch fdC, fdIsC := regC(rd, REG_F0); {
+		case fdIsC && rs1IsC && uimmFits(imm, 8, 256):
+			return encodeCLD(0b001, rs1C, fdC, imm), true // C.FLD
+		case rs1 == REG_SP && uimmFits(imm, 8, 512):
+			return encodeCLDSP(0b001, regF(rd), imm), true // C.FLDSP
+		}
+
+	case AFSD:
+		if !isIntReg(rd) || !isFloatReg(rs1) {
+			break
+		}
+		switch fsC, fsIsC := regC(rs1, REG_F0); {
+		case rdIsC && fsIsC && uimmFits(imm, 8, 256):
+			return encodeCLD(0b101, rdC, fsC, imm), true // C.FSD
+		case rd == REG_SP && uimmFits(imm, 8, 512):
+			return encodeCSDSP(0b101, regF(rs1), imm), true // C.FSDSP
+		}
+
+	case AJALR:
+		if !isIntReg(rs1) || rs1 == REG_ZERO || imm != 0 {
+			break
+		}
+		switch rd {
+		case REG_ZERO:
+			return encodeCR(0b1000, regI(rs1), 0), true // C.JR
+		case REG_RA:
+			return encodeCR(0b1001, regI(rs1), 0), true // C.JALR
+		}
+
+	case AJAL:
+		if rd == REG_ZERO && imm%2 == 0 && -maxCJumpOffset <= imm && imm < maxCJumpOffset {
+			return encodeCJ(imm), true // C.J
+		}
+
+	case ABEQ, ABNE:
+		// For branches rs2 is the first source register.
+		if imm%2 != 0 || imm < -maxCBranchOffset || imm >= maxCBranchOffset {
+			break
+		}
+		funct3 := uint32(0b110)
+		if ins.as == ABNE {
+			funct3 = 0b111
+		}
+		switch {
+		case rs1 == REG_ZERO && rs2IsC:
+			return encodeCB(funct3, rs2C, imm), true // C.BEQZ, C.BNEZ
+		case rs2 == REG_ZERO && rs1IsC:
+			return encodeCB(funct3, rs1C, imm), true // C.BEQZ, C.BNEZ
+		}
+
+	case AEBREAK:
+		return 0x9002, true // C.EBREAK
+	}
+
+	return 0, false
+}
@@ -317,6 +317,11 @@ const (
 	// it is the first instruction in an AUIPC + S-type pair that needs a
 	// R_RISCV_PCREL_STYPE relocation.
 	NEED_PCREL_STYPE_RELOC
+
+	// NO_COMPRESS is set on instructions that must keep their 32 bit
+	// encoding, such as branches that are out of range of the compressed
+	// ones and instructions whose immediate is patched after layout.
+	NO_COMPRESS
 )
 
 // RISC-V mnemonics, as defined in the "opcodes" and "opcodes-pseudo" files
@@ -652,6 +652,8 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					// We may have made previous branches too long,
 					// so recheck them.
 					rescan = true
+				} else if compressBranch(p, offset) {
+					rescan = true
 				}
 			case AJAL:
 				// Linker will handle the intersymbol case and trampolines.
@@ -665,6 +667,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					jmp.As = AJALR
 					jmp.From = p.From
 					jmp.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_TMP}
+					jmp.Mark |= NO_COMPRESS
 
 					p.As = AAUIPC
 					p.Mark = (p.Mark &^ NEED_JAL_RELOC) | NEED_CALL_RELOC
@@ -685,6 +688,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					jmp.As = AJALR
 					jmp.From = p.From
 					jmp.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_TMP}
+					jmp.Mark |= NO_COMPRESS
 
 					// p.From is not generally valid, however will be
 					// fixed up in the next loop.
@@ -694,6 +698,8 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 					p.Reg = obj.REG_NONE
 					p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_TMP}
 
+					rescan = true
+				} else if compressBranch(p, offset) {
 					rescan = true
 				}
 			}
@@ -1061,6 +1067,13 @@ func immI(as obj.As, imm int64, nbits uint) uint32 {
 	return uint32(imm)
 }
 
+// wantCSR checks that csr is a valid 12 bit CSR number.
+func wantCSR(ctxt *obj.Link, ins *instruction, csr int64) {
+	if csr < 0 || csr > 0xfff {
+		ctxt.Diag("%v: CSR %#x must be in range [0, 0xfff]", ins, csr)
+	}
+}
+
 func wantImmI(ctxt *obj.Link, ins *instruction, imm int64, nbits uint) {
 	if err := immIFits(imm, nbits); err != nil {
 		ctxt.Diag("%v: %v", ins, err)
@@ -1169,6 +1182,24 @@ func validateIII(ctxt *obj.Link, ins *instruction) {
 	wantNoneReg(ctxt, ins, "rs3", ins.rs3)
 }
 
+func validateCSRII(ctxt *obj.Link, ins *instruction) {
+	wantCSR(ctxt, ins, ins.imm)
+	wantIntReg(ctxt, ins, "rd", ins.rd)
+	wantIntReg(ctxt, ins, "rs1", ins.rs1)
+	wantNoneReg(ctxt, ins, "rs2", ins.rs2)
+	wantNoneReg(ctxt, ins, "rs3", ins.rs3)
+}
+
+func validateCSRI(ctxt *obj.Link, ins *instruction) {
+	wantCSR(ctxt, ins, ins.imm)
+	wantIntReg(ctxt, ins, "rd", ins.rd)
+	if ins.rs1 > 31 {
+		ctxt.Diag("%v: unsigned immediate %d must be in range [0, 31]", ins, ins.rs1)
+	}
+	wantNoneReg(ctxt, ins, "rs2", ins.rs2)
+	wantNoneReg(ctxt, ins, "rs3", ins.rs3)
+}
+
 func validateIF(ctxt *obj.Link, ins *instruction) {
 	wantImmI(ctxt, ins, ins.imm, 12)
 	wantFloatReg(ctxt, ins, "rd", ins.rd)
@@ -1311,6 +1342,16 @@ func encodeIII(ins *instruction) uint32 {
 	return encodeI(ins.as, regI(ins.rs1), regI(ins.rd), uint32(ins.imm))
 }
 
+func encodeCSRII(ins *instruction) uint32 {
+	return encodeI(ins.as, regI(ins.rs1), regI(ins.rd), uint32(ins.imm))
+}
+
+// encodeCSRI encodes a CSR instruction with an immediate source operand,
+// which is held in rs1 as a 5 bit unsigned value.
+func encodeCSRI(ins *instruction) uint32 {
+	return encodeI(ins.as, ins.rs1, regI(ins.rd), uint32(ins.imm))
+}
+
 func encodeIF(ins *instruction) uint32 {
 	return encodeI(ins.as, regI(ins.rs1), regF(ins.rd), uint32(ins.imm))
 }
@@ -1511,6 +1552,12 @@ var (
 	iIIEncoding = encoding{encode: encodeIII, validate: validateIII, length: 4}
 	iFEncoding  = encoding{encode: encodeIF, validate: validateIF, length: 4}
 
+	// CSR instructions are I-type instructions with an unsigned 12 bit CSR
+	// number as immediate and either an integer register or a 5 bit
+	// unsigned immediate as source.
+	csrIIEncoding = encoding{encode: encodeCSRII, validate: validateCSRII, length: 4}
+	csrIEncoding  = encoding{encode: encodeCSRI, validate: validateCSRI, length: 4}
+
 	sIEncoding = encoding{encode: encodeSI, validate: validateSI, length: 4}
 	sFEncoding = encoding{encode: encodeSF, validate: validateSF, length: 4}
 
@@ -1605,7 +1652,12 @@ var instructions = [ALAST & obj.AMask]instructionData{
 	ASD & obj.AMask: {enc: sIEncoding},
 
 	// 7.1: CSR Instructions
-	ACSRRS & obj.AMask: {enc: iIIEncoding},
+	ACSRRW & obj.AMask:  {enc: csrIIEncoding, immForm: ACSRRWI},
+	ACSRRS & obj.AMask:  {enc: csrIIEncoding, immForm: ACSRRSI},
+	ACSRRC & obj.AMask:  {enc: csrIIEncoding, immForm: ACSRRCI},
+	ACSRRWI & obj.AMask: {enc: csrIEncoding},
+	ACSRRSI & obj.AMask: {enc: csrIEncoding},
+	ACSRRCI & obj.AMask: {enc: csrIEncoding},
 
 	// 7.1: Multiplication Operations
 	AMUL & obj.AMask:    {enc: rIIIEncoding, ternary: true},
@@ -1856,6 +1908,9 @@ func (ins *instruction) encode() (uint32, error) {
 	if enc.length <= 0 {
 		return 0, fmt.Errorf("%v: encoding called for a pseudo instruction", ins.as)
 	}
+	if c, ok := ins.compress(); ok {
+		return uint32(c), nil
+	}
 	return enc.encode(ins), nil
 }
 
@@ -1864,6 +1919,11 @@ func (ins *instruction) length() int {
 	if err != nil {
 		return 0
 	}
+	if enc.length > 0 {
+		if _, ok := ins.compress(); ok {
+			return 2
+		}
+	}
 	return enc.length
 }
 
@@ -1873,6 +1933,12 @@ func (ins *instruction) validate(ctxt *obj.Link) {
 		ctxt.Diag(err.Error())
 		return
 	}
+	if buildcfg.GORISCV64 == 0 && ins.as >= AFLW && ins.as <= AFCLASSQ {
+		// RV64IMA targets may not implement the F, D and Q extensions at
+		// all, reject rather than emit instructions they would ignore.
+		ctxt.Diag("%v: floating point instruction not supported with GORISCV64=rv64ima", ins)
+		return
+	}
 	enc.validate(ctxt, ins)
 }
 
@@ -2416,11 +2482,36 @@ func instructionsForProg(p *obj.Prog) []*instruction {
 		ins.rs1 = REG_ZERO
 		switch p.As {
 		case ARDCYCLE:
-			ins.imm = -1024
+			ins.imm = 0xc00
 		case ARDTIME:
-			ins.imm = -1023
+			ins.imm = 0xc01
 		case ARDINSTRET:
-			ins.imm = -1022
+			ins.imm = 0xc02
+		}
+
+	case ACSRRW, ACSRRS, ACSRRC, ACSRRWI, ACSRRSI, ACSRRCI:
+		// CSRRW RS1, $csr, RD
+		// CSRRWI $uimm, $csr, RD
+		if len(p.RestArgs) != 1 || p.RestArgs[0].Type != obj.TYPE_CONST {
+			p.Ctxt.Diag("%v: second operand must be a CSR number", p)
+			return nil
+		}
+		ins.imm = p.RestArgs[0].Offset
+		ins.rs2, ins.rs3 = obj.REG_NONE, obj.REG_NONE
+
+		switch ins.as {
+		case ACSRRWI, ACSRRSI, ACSRRCI:
+			if p.From.Type != obj.TYPE_CONST || p.From.Offset < 0 || p.From.Offset > 31 {
+				p.Ctxt.Diag("%v: immediate out of range 0 to 31", p)
+				return nil
+			}
+			ins.rs1 = uint32(p.From.Offset)
+		default:
+			if p.From.Type != obj.TYPE_REG {
+				p.Ctxt.Diag("%v: first operand must be a register", p)
+				return nil
+			}
+			ins.rs1 = uint32(p.From.Reg)
 		}
 
 	case AFENCE:
@@ -2666,6 +2757,10 @@ func assemble(ctxt *obj.Link, cursym *obj.LSym, newprog obj.ProgAlloc) {
 				cursym.WriteBytes(ctxt, offset, []byte{0x13, 0, 0, 0})
 				offset += 4
 			}
+			if v == 2 {
+				// C.NOP, only needed after compressed instructions.
+				cursym.WriteBytes(ctxt, offset, []byte{0x01, 0})
+			}
 			continue
 		}
 
@@ -4,7 +4,10 @@
 
 package sys
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"internal/buildcfg"
+)
 
 // ArchFamily represents a family of one or more related architectures.
 // For example, ppc64 and ppc64le are both members of the PPC64 family.
@@ -234,13 +237,22 @@ var ArchRISCV64 = &Arch{
 	ByteOrder:      binary.LittleEndian,
 	PtrSize:        8,
 	RegSize:        8,
-	MinLC:          4,
+	MinLC:          riscv64MinLC(),
 	Alignment:      8, // riscv unaligned loads work, but are really slow (trap + simulated by OS)
 	CanMergeLoads:  false,
 	HasLR:          true,
 	FixedFrameSize: 8, // LR
 }
 
+// riscv64MinLC returns the RISC-V instruction length, compressed instructions
+// are 2 bytes long.
+func riscv64MinLC() int {
+	if buildcfg.GORISCV64RVC {
+		return 2
+	}
+	return 4
+}
+
 var ArchS390X = &Arch{
 	Name:           "s390x",
 	Family:         S390X,
@@ -72,6 +72,12 @@ Flags:
 		system tools now assume the presence of the header.
 	-dumpdep
 		Dump symbol dependency graph.
+	-env key=value
+		Set the environment variable key to value in the linked program,
+		as seen by the runtime (GODEBUG, GOGC, GOMEMLIMIT, GOTRACEBACK)
+		and by os.Getenv. May be repeated, a later definition of the same
+		key replaces an earlier one. Only supported on GOOS=tamago, which
+		has no process environment.
 	-extar ar
 		Set the external archive program (default "ar").
 		Used only for -buildmode=c-archive.
@@ -2870,6 +2870,16 @@ func (ctxt *Link) address() []*sym.Segment {
 	}
 	order = append(order, &Segdata)
 	Segdata.Rwx = 06
+	if *FlagDataAddr != -1 {
+		// The data segment is linked at a distinct (RAM) address, its
+		// initial contents are loaded after the read-only segments and
+		// copied at boot by the runtime.
+		if *FlagDataAddr%*FlagRound != 0 {
+			Exitf("-D address %#x is not aligned to the -R rounding quantum %#x", *FlagDataAddr, *FlagRound)
+		}
+		Segdata.Laddr = va
+		va = uint64(*FlagDataAddr)
+	}
 	Segdata.Vaddr = va
 	var data *sym.Section
 	var noptr *sym.Section
@@ -3056,6 +3066,16 @@ func (ctxt *Link) address() []*sym.Segment {
 	ctxt.xdefine("runtime.covctrs", sym.SCOVERAGE_COUNTER, int64(noptrbss.Vaddr+covCounterDataStartOff))
 	ctxt.xdefine("runtime.ecovctrs", sym.SCOVERAGE_COUNTER, int64(noptrbss.Vaddr+covCounterDataStartOff+covCounterDataLen))
 	ctxt.xdefine("runtime.end", sym.SBSS, int64(Segdata.Vaddr+Segdata.Length))
+	if ctxt.HeadType == objabi.Htamago {
+		dataload, sect := Segdata.Vaddr, Segdata.Sections[0]
+		if Segdata.Laddr != 0 {
+			dataload, sect = Segdata.Laddr, dataloadSect(ldr)
+		}
+		ctxt.xdefine("runtime.datastart", sym.SDATA, int64(Segdata.Vaddr))
+		ctxt.xdefine("runtime.dataload", sym.SRODATA, int64(dataload))
+		ldr.SetSymSect(ldr.Lookup("runtime.datastart", 0), Segdata.Sections[0])
+		ldr.SetSymSect(ldr.Lookup("runtime.dataload", 0), sect)
+	}
 
 	if fuzzCounters != nil {
 		if *flagAsan {
@@ -1138,6 +1138,9 @@ func elfphload(seg *sym.Segment) *ElfPhdr {
 	}
 	ph.Vaddr = seg.Vaddr
 	ph.Paddr = seg.Vaddr
+	if seg.Laddr != 0 {
+		ph.Paddr = seg.Laddr
+	}
 	ph.Memsz = seg.Length
 	ph.Off = seg.Fileoff
 	ph.Filesz = seg.Filelen
@@ -1146,6 +1149,33 @@ func elfphload(seg *sym.Segment) *ElfPhdr {
 	return ph
 }
 
+// dataload is the section of the data segment load image (-D), it only holds
+// runtime.dataload as its contents are those of the data segment.
+var dataload *sym.Section
+
+func dataloadSect(ldr *loader.Loader) *sym.Section {
+	if dataload == nil {
+		dataload = ldr.NewSection()
+		dataload.Name = ".go.dataload"
+		dataload.Rwx = 04
+		dataload.Seg = &Segdata
+		dataload.Vaddr = Segdata.Laddr
+	}
+	return dataload
+}
+
+// elfshload describes the initial contents of a segment at its load address,
+// for loaders which use section rather than program headers.
+func elfshload(seg *sym.Segment, name string) {
+	sh := elfshname(name)
+	sh.Type = uint32(elf.SHT_PROGBITS)
+	sh.Flags = uint64(elf.SHF_ALLOC)
+	sh.Addr = seg.Laddr
+	sh.Addralign = uint64(*FlagRound)
+	sh.Size = seg.Filelen
+	sh.Off = seg.Fileoff
+}
+
 func elfphrelro(seg *sym.Segment) {
 	ph := newElfPhdr()
 	ph.Type = elf.PT_GNU_RELRO
@@ -1495,6 +1525,9 @@ func (ctxt *Link) doelf() {
 	if buildcfg.GOOS == "tamago" {
 		shstrtabAddstring(".note.go.pvh")
 	}
+	if *FlagDataAddr != -1 {
+		shstrtabAddstring(".go.dataload")
+	}
 	shstrtabAddstring(".elfdata")
 	shstrtabAddstring(".rodata")
 	// See the comment about data.rel.ro.FOO section names in data.go.
@@ -1815,6 +1848,9 @@ func Asmbelfsetup() {
 	for _, sect := range Segdata.Sections {
 		elfshalloc(sect)
 	}
+	if dataload != nil {
+		elfshalloc(dataload)
+	}
 	for _, sect := range Segdwarf.Sections {
 		elfshalloc(sect)
 	}
@@ -2288,6 +2324,9 @@ elfobj:
 	for _, sect := range Segdata.Sections {
 		elfshbits(ctxt.LinkMode, sect)
 	}
+	if Segdata.Laddr != 0 {
+		elfshload(&Segdata, ".go.dataload")
+	}
 	for _, sect := range Segdwarf.Sections {
 		elfshbits(ctxt.LinkMode, sect)
 	}
@@ -6,6 +6,7 @@ package ld
 
 import (
 	"bytes"
+	"debug/elf"
 	"debug/pe"
 	"fmt"
 	"internal/testenv"
@@ -439,3 +440,305 @@ func d()
 		t.Errorf("Trampoline b-tramp0 exists unnecessarily")
 	}
 }
+
+func TestAddEnv(t *testing.T) {
+	defer func(env []string) { flagEnv = env }(flagEnv)
+	flagEnv = nil
+
+	for _, kv := range []string{"GOGC=off", "GODEBUG=a=1,b=2", "GOGC=50", "EMPTY="} {
+		addenv(kv)
+	}
+	want := []string{"GOGC=50", "GODEBUG=a=1,b=2", "EMPTY="}
+	if strings.Join(flagEnv, " ") != strings.Join(want, " ") {
+		t.Errorf("got environment %q, want %q", flagEnv, want)
+	}
+}
+
+func TestTamagoFlagsNonTamago(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	if runtime.GOOS == "tamago" {
+		t.Skip("flags are supported on tamago")
+	}
+
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "main.go")
+	if err := os.WriteFile(src, []byte("package main\nfunc main() {}\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		flag string
+		arg  string
+	}{
+		{"env", "GOGC=off"},
+		{"D", "0x10000000"},
+		{"region", "ram=ram:0x10000000:0x1000000"},
+	} {
+		t.Run(test.flag, func(t *testing.T) {
+			t.Parallel()
+			cmd := testenv.Command(t, testenv.GoToolPath(t), "build", "-ldflags=-"+test.flag+"="+test.arg, "-o", filepath.Join(tmpdir, test.flag), src)
+			out, err := cmd.CombinedOutput()
+			if err == nil || !bytes.Contains(out, []byte("-"+test.flag+" is only supported on tamago")) {
+				t.Errorf("expected -%s to be rejected, got err=%v, output:\n%s", test.flag, err, out)
+			}
+		})
+	}
+}
+
+// tamagoBoard is a minimal tamago/riscv64 board, providing the hooks the
+// runtime expects from the linked application.
+var tamagoBoard = map[string]string{
+	"go.mod": "module board\n",
+	"cpu.s": `#include "textflag.h"
+
+TEXT cpuinit(SB),NOSPLIT|NOFRAME,$0
+	JMP	runtime·rt0_riscv64_tamago(SB)
+`,
+	"main.go": `package main
+
+import _ "unsafe"
+
+//go:linkname ramStart runtime.ramStart
+var ramStart uint64 = 0xa0100000
+
+//go:linkname ramSize runtime.ramSize
+var ramSize uint64 = 0x1000000
+
+//go:linkname ramStackOffset runtime.ramStackOffset
+var ramStackOffset uint64 = 0x100
+
+//go:linkname hwinit0 runtime.hwinit0
+func hwinit0() {}
+
+//go:linkname hwinit1 runtime.hwinit1
+func hwinit1() {}
+
+//go:linkname printk runtime.printk
+func printk(c byte) {}
+
+//go:linkname initRNG runtime.initRNG
+func initRNG() {}
+
+//go:linkname getRandomData runtime.getRandomData
+func getRandomData(b []byte) {}
+
+//go:linkname nanotime1 runtime.nanotime1
+func nanotime1() int64 { return 0 }
+
+var x = 42
+
+func main() { println(x) }
+`,
+}
+
+// buildTamago links the tamagoBoard program for tamago/riscv64 with ldflags,
+// returning the binary path and the build output.
+func buildTamago(t *testing.T, ldflags string) (string, []byte, error) {
+	t.Helper()
+
+	tmpdir := t.TempDir()
+	for name, src := range tamagoBoard {
+		if err := os.WriteFile(filepath.Join(tmpdir, name), []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bin := filepath.Join(tmpdir, "board.elf")
+	cmd := testenv.Command(t, testenv.GoToolPath(t), "build", "-ldflags="+ldflags, "-o", bin, ".")
+	cmd.Dir = tmpdir
+	cmd.Env = append(os.Environ(), "GOOS=tamago", "GOARCH=riscv64")
+	out, err := cmd.CombinedOutput()
+	return bin, out, err
+}
+
+func TestEnvFlagTamago(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	bin, out, err := buildTamago(t, "-env=GOGC=off -env=GODEBUG=a=1 -env=GOGC=50")
+	if err != nil {
+		t.Fatalf("build failed: %v, output:\n%s", err, out)
+	}
+
+	f, err := elf.Open(bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	syms, err := f.Symbols()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(addr, size uint64) []byte {
+		for _, p := range f.Progs {
+			if p.Type == elf.PT_LOAD && addr >= p.Vaddr && addr+size <= p.Vaddr+p.Filesz {
+				b := make([]byte, size)
+				if _, err := p.ReadAt(b, int64(addr-p.Vaddr)); err != nil {
+					t.Fatal(err)
+				}
+				return b
+			}
+		}
+		t.Fatalf("[%#x, %#x) is not loaded", addr, addr+size)
+		return nil
+	}
+
+	// the string header read by runtime.goenvs and buildGetenv
+	for _, s := range syms {
+		if s.Name != "runtime.buildEnv" {
+			continue
+		}
+		hdr := read(s.Value, 16)
+		env := read(f.ByteOrder.Uint64(hdr), f.ByteOrder.Uint64(hdr[8:]))
+		if want := "GOGC=50\x00GODEBUG=a=1"; string(env) != want {
+			t.Errorf("runtime.buildEnv = %q, want %q", env, want)
+		}
+		return
+	}
+	t.Errorf("runtime.buildEnv not found")
+}
+
+func TestDataAddrFlagTamago(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	const dataAddr = 0x10000000
+
+	bin, out, err := buildTamago(t, fmt.Sprintf("-D %#x", dataAddr))
+	if err != nil {
+		t.Fatalf("build failed: %v, output:\n%s", err, out)
+	}
+
+	f, err := elf.Open(bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// the data segment is linked at -D and loaded after the read-only data
+	var data *elf.Prog
+	var rodataEnd uint64
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_LOAD {
+			continue
+		}
+		if p.Flags&elf.PF_W != 0 {
+			data = p
+		} else {
+			rodataEnd = max(rodataEnd, p.Paddr+p.Memsz)
+		}
+	}
+	if data == nil {
+		t.Fatal("no data PT_LOAD")
+	}
+	if data.Vaddr != dataAddr || data.Paddr == data.Vaddr || data.Paddr < rodataEnd {
+		t.Errorf("data PT_LOAD VirtAddr %#x PhysAddr %#x, want VirtAddr %#x and PhysAddr after the read-only data (%#x)", data.Vaddr, data.Paddr, uint64(dataAddr), rodataEnd)
+	}
+
+	// the load image section and symbol, used by the runtime to copy the
+	// data at boot
+	if s := f.Section(".go.dataload"); s == nil || s.Addr != data.Paddr || s.Size != data.Filesz {
+		t.Errorf(".go.dataload section %+v, want address %#x and size %#x", s, data.Paddr, data.Filesz)
+	}
+	syms, err := f.Symbols()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]uint64{"runtime.datastart": data.Vaddr, "runtime.dataload": data.Paddr}
+	for _, s := range syms {
+		if v, ok := want[s.Name]; ok {
+			if s.Value != v {
+				t.Errorf("%s = %#x, want %#x", s.Name, s.Value, v)
+			}
+			delete(want, s.Name)
+		}
+	}
+	for name := range want {
+		t.Errorf("%s not found", name)
+	}
+}
+
+func TestDataAddrFlagTamagoNonRISCV64(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	tmpdir := t.TempDir()
+	src := filepath.Join(tmpdir, "main.go")
+	if err := os.WriteFile(src, []byte("package main\nfunc main() {}\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, goarch := range []string{"amd64", "arm"} {
+		t.Run(goarch, func(t *testing.T) {
+			t.Parallel()
+			cmd := testenv.Command(t, testenv.GoToolPath(t), "build", "-ldflags=-D=0x10000000", "-o", filepath.Join(tmpdir, goarch), src)
+			cmd.Env = append(os.Environ(), "GOOS=tamago", "GOARCH="+goarch)
+			out, err := cmd.CombinedOutput()
+			if err == nil || !bytes.Contains(out, []byte("-D is only supported on tamago/riscv64")) {
+				t.Errorf("expected -D to be rejected, got err=%v, output:\n%s", err, out)
+			}
+		})
+	}
+}
+
+func TestMemAreaCheck(t *testing.T) {
+	regions := []memRegion{
+		{"rom", "rom", 0x80000000, 0x88000000},
+		{"input", "reserved", 0x90000000, 0x90002000},
+		{"ram", "ram", 0xa0000000, 0xc0000000},
+		{"output", "reserved", 0xa0010000, 0xa0020000},
+	}
+	for _, test := range []struct {
+		area memArea
+		want string
+	}{
+		{memArea{"text", 0x80000000, 0x80100000, false}, ""},
+		{memArea{"data", 0xa0020000, 0xa0030000, true}, ""},
+		{memArea{"rodata", 0xa0020000, 0xa0030000, false}, ""},
+		{memArea{"data", 0x80100000, 0x80110000, true}, "data [0x80100000, 0x80110000) is writable but placed in rom region rom [0x80000000, 0x88000000)"},
+		{memArea{"data", 0xa000f000, 0xa0011000, true}, "data [0xa000f000, 0xa0011000) overlaps reserved region output [0xa0010000, 0xa0020000)"},
+		{memArea{"rodata", 0x8fff0000, 0x90001000, false}, "rodata [0x8fff0000, 0x90001000) overlaps reserved region input [0x90000000, 0x90002000)"},
+		{memArea{"text", 0x87ff0000, 0x88010000, false}, "text [0x87ff0000, 0x88010000) exceeds rom region rom [0x80000000, 0x88000000)"},
+		{memArea{"stack", 0xbfff0000, 0xc0010000, true}, "stack [0xbfff0000, 0xc0010000) exceeds ram region ram [0xa0000000, 0xc0000000)"},
+		{memArea{"text", 0x10000, 0x20000, false}, "text [0x10000, 0x20000) is outside of any memory region"},
+	} {
+		got := ""
+		if err := test.area.check(regions); err != nil {
+			got = err.Error()
+		}
+		if got != test.want {
+			t.Errorf("%v: got error %q, want %q", test.area, got, test.want)
+		}
+	}
+}
+
+func TestRegionFlagTamago(t *testing.T) {
+	testenv.MustHaveGoBuild(t)
+	t.Parallel()
+
+	// the text is linked at the default 0x11000, its program header maps
+	// the ELF headers from 0x10000
+	const ram = "-D 0x10000000 -region ram=ram:0x10000000:0xa0000000"
+	for _, test := range []struct {
+		rom  string
+		want string
+	}{
+		{"0x10000", ""},
+		{"0x11000", "text segment [0x10000, "},
+	} {
+		t.Run(test.rom, func(t *testing.T) {
+			t.Parallel()
+			_, out, err := buildTamago(t, ram+" -region rom=rom:"+test.rom+":0x1000000")
+			switch {
+			case test.want == "" && err != nil:
+				t.Errorf("build failed: %v, output:\n%s", err, out)
+			case test.want != "" && (err == nil || !bytes.Contains(out, []byte(test.want))):
+				t.Errorf("expected %q error, got err=%v, output:\n%s", test.want, err, out)
+			}
+		})
+	}
+}
@@ -61,6 +61,25 @@ func init() {
 	flag.Var(&flagW, "w", "disable DWARF generation")
 }
 
+// flagEnv holds the environment variables set with -env.
+var flagEnv []string
+
+// addenv records an environment variable definition, a later definition of
+// the same key replaces the earlier one.
+func addenv(arg string) {
+	key, _, ok := strings.Cut(arg, "=")
+	if !ok || key == "" || strings.Contains(arg, "\x00") {
+		Exitf("-env flag requires argument of the form key=value")
+	}
+	for i, kv := range flagEnv {
+		if k, _, _ := strings.Cut(kv, "="); k == key {
+			flagEnv[i] = arg
+			return
+		}
+	}
+	flagEnv = append(flagEnv, arg)
+}
+
 // Flags used by the linker. The exported flags are used by the architecture-specific packages.
 var (
 	flagBuildid = flag.String("buildid", "", "record `id` as Go toolchain build id")
@@ -105,6 +124,7 @@ var (
 	FlagStrictDups    = flag.Int("strictdups", 0, "sanity check duplicate symbol contents during object file reading (1=warn 2=err).")
 	FlagRound         = flag.Int64("R", -1, "set address rounding `quantum`")
 	FlagTextAddr      = flag.Int64("T", -1, "set the start address of text symbols")
+	FlagDataAddr      = flag.Int64("D", -1, "set the start address of data symbols, loaded after read-only data (tamago/riscv64 only)")
 	flagEntrySymbol   = flag.String("E", "", "set `entry` symbol name")
 	flagPruneWeakMap  = flag.Bool("pruneweakmap", true, "prune weak mapinit refs")
 	flagRandLayout    = flag.Int64("randlayout", 0, "randomize function layout")
@@ -201,6 +221,8 @@ func Main(arch *sys.Arch, theArch Arch) {
 	objabi.Flagfn1("L", "add specified `directory` to library path", func(a string) { Lflag(ctxt, a) })
 	objabi.AddVersionFlag() // -V
 	objabi.Flagfn1("X", "add string value `definition` of the form importpath.name=value", func(s string) { addstrdata1(ctxt, s) })
+	objabi.Flagfn1("env", "add environment variable `definition` of the form key=value (tamago only)", addenv)
+	objabi.Flagfn1("region", "add memory region `definition` of the form name=kind:start:size, checked against the program layout (tamago only)", addregion)
 	objabi.Flagcount("v", "print link trace", &ctxt.Debugvlog)
 	objabi.Flagfn1("importcfg", "read import configuration from `file`", ctxt.readImportCfg)
 
@@ -253,6 +275,27 @@ func Main(arch *sys.Arch, theArch Arch) {
 
 	checkStrictDups = *FlagStrictDups
 
+	if *FlagDataAddr != -1 {
+		switch {
+		case buildcfg.GOOS != "tamago":
+			Exitf("-D is only supported on tamago")
+		case buildcfg.GOARCH != "riscv64":
+			// the runtime copies the data at boot on riscv64 only
+			Exitf("-D is only supported on tamago/riscv64")
+		}
+	}
+
+	if len(flagEnv) > 0 {
+		if buildcfg.GOOS != "tamago" {
+			Exitf("-env is only supported on tamago")
+		}
+		addstrdata1(ctxt, "runtime.buildEnv="+strings.Join(flagEnv, "\x00"))
+	}
+
+	if len(flagRegions) > 0 && buildcfg.GOOS != "tamago" {
+		Exitf("-region is only supported on tamago")
+	}
+
 	switch flagW {
 	case ternaryFlagFalse:
 		*FlagW = false
@@ -433,6 +476,10 @@ func Main(arch *sys.Arch, theArch Arch) {
 	ctxt.dodata(symGroupType)
 	bench.Start("address")
 	order := ctxt.address()
+	if len(flagRegions) > 0 {
+		bench.Start("checkMemMap")
+		ctxt.checkMemMap()
+	}
 	bench.Start("dwarfcompress")
 	dwarfcompress(ctxt)
 	bench.Start("layout")
@@ -0,0 +1,215 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ld
+
+import (
+	"cmd/link/internal/sym"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Board memory map validation (tamago only).
+//
+// A tamago program is linked at fixed addresses and relies on the board to
+// place its heap (runtime.Bloc) and stack (runtime.ramStart, ramSize,
+// ramStackOffset and g0StackSize), nothing otherwise prevents the text, data
+// or heap from landing on a memory mapped window of the target. The -region
+// flag declares the board memory regions, when any is present the loadable
+// segments, as mapped by their program headers, the heap start and the stack
+// window are checked against them.
+
+// memRegion is a board memory region declared with -region.
+type memRegion struct {
+	name  string
+	kind  string // "rom", "ram" or "reserved"
+	start uint64
+	end   uint64
+}
+
+func (r memRegion) String() string {
+	return fmt.Sprintf("%s region %s [%#x, %#x)", r.kind, r.name, r.start, r.end)
+}
+
+// flagRegions holds the memory regions declared with -region.
+var flagRegions []memRegion
+
+// addregion records a memory region definition of the form
+// name=kind:start:size.
+func addregion(arg string) {
+	name, def, _ := strings.Cut(arg, "=")
+	f := strings.Split(def, ":")
+	if name == "" || len(f) != 3 {
+		Exitf("-region flag requires argument of the form name=kind:start:size")
+	}
+	switch f[0] {
+	case "rom", "ram", "reserved":
+	default:
+		Exitf("-region %s: invalid kind %q, must be rom, ram or reserved", name, f[0])
+	}
+	start, err := strconv.ParseUint(f[1], 0, 64)
+	if err != nil {
+		Exitf("-region %s: invalid start address %q", name, f[1])
+	}
+	size, err := strconv.ParseUint(f[2], 0, 64)
+	if err != nil || size == 0 || start+size < start {
+		Exitf("-region %s: invalid size %q", name, f[2])
+	}
+	flagRegions = append(flagRegions, memRegion{name: name, kind: f[0], start: start, end: start + size})
+}
+
+// memArea is an address range used by the program.
+type memArea struct {
+	name     string
+	start    uint64
+	end      uint64
+	writable bool
+}
+
+func (a memArea) String() string {
+	return fmt.Sprintf("%s [%#x, %#x)", a.name, a.start, a.end)
+}
+
+func (a memArea) overlaps(start, end uint64) bool {
+	return a.start < end && start < a.end
+}
+
+// check returns an error if the area overlaps a reserved region or is not
+// contained in a single rom or ram region, writable areas must be in ram.
+func (a memArea) check(regions []memRegion) error {
+	for _, r := range regions {
+		if r.kind == "reserved" && a.overlaps(r.start, r.end) {
+			return fmt.Errorf("%v overlaps %v", a, r)
+		}
+	}
+	var partial *memRegion
+	for i, r := range regions {
+		switch {
+		case r.kind == "reserved":
+			continue
+		case a.start >= r.start && a.end <= r.end:
+			if a.writable && r.kind != "ram" {
+				return fmt.Errorf("%v is writable but placed in %v", a, r)
+			}
+			return nil
+		case a.overlaps(r.start, r.end):
+			partial = &regions[i]
+		}
+	}
+	if partial != nil {
+		return fmt.Errorf("%v exceeds %v", a, *partial)
+	}
+	return fmt.Errorf("%v is outside of any memory region", a)
+}
+
+// checkMemMap validates the program layout against the -region memory map,
+// it must be called once addresses have been assigned.
+func (ctxt *Link) checkMemMap() {
+	var segs []memArea
+	for _, seg := range []struct {
+		name string
+		*sym.Segment
+	}{
+		{"text segment", &Segtext},
+		{"rodata segment", &Segrodata},
+		{"relrodata segment", &Segrelrodata},
+		{"data segment", &Segdata},
+	} {
+		if seg.Length == 0 {
+			continue
+		}
+		start := loadStart(seg.Segment)
+		segs = append(segs, memArea{
+			name:     seg.name,
+			start:    start,
+			end:      seg.Vaddr + seg.Length,
+			writable: seg.Rwx&02 != 0,
+		})
+		if seg.Laddr != 0 {
+			segs = append(segs, memArea{
+				name:  seg.name + " load image",
+				start: seg.Laddr - (seg.Vaddr - start),
+				end:   seg.Laddr + seg.Filelen,
+			})
+		}
+	}
+
+	// The heap starts at runtime.Bloc, or at the end of the data segment
+	// when not set by the board, and grows up to the stack window.
+	heap := memArea{name: "heap", start: ctxt.memMapVar("runtime.Bloc"), writable: true}
+	if heap.start == 0 {
+		heap.start = Segdata.Vaddr + Segdata.Length
+	}
+	heap.end = heap.start + 1
+
+	var ram []memArea
+	runtime := []memArea{heap}
+
+	if ramSize := ctxt.memMapVar("runtime.ramSize"); ramSize != 0 {
+		r := memArea{name: "runtime RAM", start: ctxt.memMapVar("runtime.ramStart"), writable: true}
+		r.end = r.start + ramSize
+		ram = append(ram, r)
+
+		g0 := ctxt.memMapVar("runtime.g0StackSize")
+		if g0 == 0 {
+			g0 = 64 << 10 // runtime.defaultG0StackSize
+		}
+		stack := memArea{name: "stack", start: r.end - ctxt.memMapVar("runtime.ramStackOffset") - g0, end: r.end, writable: true}
+		switch {
+		case stack.start < r.start || stack.start > r.end:
+			Errorf("%v does not fit in %v", stack, r)
+		case heap.start >= stack.start:
+			Errorf("heap start %#x is not below the %v", heap.start, stack)
+		default:
+			runtime[0].end = stack.start
+			runtime = append(runtime, stack)
+		}
+	}
+
+	for _, a := range append(append(segs, ram...), runtime...) {
+		if err := a.check(flagRegions); err != nil {
+			Errorf("%v", err)
+		}
+	}
+	for _, a := range runtime {
+		for _, s := range segs {
+			if a.overlaps(s.start, s.end) {
+				Errorf("%v overlaps %v", a, s)
+			}
+		}
+	}
+
+	exitIfErrors()
+}
+
+// loadStart returns the address of the PT_LOAD program header of seg, which
+// also maps the ELF headers (HEADR bytes before -T) for the text segment and
+// is rounded down to -R (see fixElfPhdr).
+func loadStart(seg *sym.Segment) uint64 {
+	vaddr := seg.Vaddr
+	if seg == &Segtext {
+		vaddr += uint64(getElfEhdr().Ehsize) - uint64(HEADR)
+	}
+	return vaddr &^ uint64(*FlagRound-1)
+}
+
+// memMapVar returns the statically initialized value of an unsigned integer
+// variable set by the board, or 0 when it is not initialized at link time.
+func (ctxt *Link) memMapVar(name string) uint64 {
+	ldr := ctxt.loader
+	s := ldr.Lookup(name, 0)
+	if s == 0 {
+		return 0
+	}
+	b := make([]byte, 8)
+	copy(b, ldr.Data(s))
+	switch ldr.SymSize(s) {
+	case 4:
+		return uint64(ctxt.Arch.ByteOrder.Uint32(b))
+	case 8:
+		return ctxt.Arch.ByteOrder.Uint64(b)
+	}
+	return 0
+}
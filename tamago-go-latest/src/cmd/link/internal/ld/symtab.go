@@ -446,6 +446,12 @@ func (ctxt *Link) symtab(pcln *pclntab) []sym.SymKind {
 	ctxt.xdefine("runtime.ecovctrs", sym.SNOPTRBSS, 0)
 	ctxt.xdefine("runtime.end", sym.SBSS, 0)
 	ctxt.xdefine("runtime.epclntab", sym.SRODATA, 0)
+	if ctxt.HeadType == objabi.Htamago {
+		// Start of the data segment and load address of its initial
+		// contents, which the runtime copies when they differ (-D).
+		ctxt.xdefine("runtime.datastart", sym.SDATA, 0)
+		ctxt.xdefine("runtime.dataload", sym.SRODATA, 0)
+	}
 	ctxt.xdefine("runtime.esymtab", sym.SRODATA, 0)
 
 	// garbage collection symbols
@@ -38,6 +38,7 @@ package sym
 type Segment struct {
 	Rwx      uint8  // permission as usual unix bits (5 = r-x etc)
 	Vaddr    uint64 // virtual address
+	Laddr    uint64 // load address, if different from Vaddr (see -D)
 	Length   uint64 // length in memory
 	Fileoff  uint64 // file offset
 	Filelen  uint64 // length on disk
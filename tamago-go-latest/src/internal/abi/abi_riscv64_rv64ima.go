@@ -0,0 +1,20 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build riscv64.rv64ima
+
+package abi
+
+const (
+	// See abi_generic.go.
+
+	// X8 - X23
+	IntArgRegs = 16
+
+	// RV64IMA has no floating point registers, floating point values are
+	// passed in integer registers (softfloat).
+	FloatArgRegs = 0
+
+	EffectiveFloatRegSize = 0
+)
@@ -20,22 +20,23 @@ import (
 )
 
 var (
-	GOROOT    = os.Getenv("GOROOT") // cached for efficiency
-	GOARCH    = envOr("GOARCH", defaultGOARCH)
-	GOOS      = envOr("GOOS", defaultGOOS)
-	GO386     = envOr("GO386", DefaultGO386)
-	GOAMD64   = goamd64()
-	GOARM     = goarm()
-	GOARM64   = goarm64()
-	GOMIPS    = gomips()
-	GOMIPS64  = gomips64()
-	GOPPC64   = goppc64()
-	GORISCV64 = goriscv64()
-	GOWASM    = gowasm()
-	ToolTags  = toolTags()
-	GO_LDSO   = defaultGO_LDSO
-	GOFIPS140 = gofips140()
-	Version   = version
+	GOROOT       = os.Getenv("GOROOT") // cached for efficiency
+	GOARCH       = envOr("GOARCH", defaultGOARCH)
+	GOOS         = envOr("GOOS", defaultGOOS)
+	GO386        = envOr("GO386", DefaultGO386)
+	GOAMD64      = goamd64()
+	GOARM        = goarm()
+	GOARM64      = goarm64()
+	GOMIPS       = gomips()
+	GOMIPS64     = gomips64()
+	GOPPC64      = goppc64()
+	GORISCV64    = goriscv64()
+	GORISCV64RVC = goriscv64rvc()
+	GOWASM       = gowasm()
+	ToolTags     = toolTags()
+	GO_LDSO      = defaultGO_LDSO
+	GOFIPS140    = gofips140()
+	Version      = version
 )
 
 // Error is one of the errors found (if any) in the build configuration.
@@ -301,15 +302,26 @@ func goppc64() int {
 	return int(DefaultGOPPC64[len("power")] - '0')
 }
 
+// rvcOpt is the GORISCV64 suffix enabling compressed instructions (RVC).
+const rvcOpt = ",rvc"
+
 func goriscv64() int {
-	switch v := envOr("GORISCV64", DefaultGORISCV64); v {
+	switch v := strings.TrimSuffix(envOr("GORISCV64", DefaultGORISCV64), rvcOpt); v {
+	case "rv64ima":
+		// RV64IMA lacks the F, D and C extensions of RVA20U64, it implies
+		// softfloat and is reported as the lowest profile.
+		return 0
 	case "rva20u64":
 		return 20
 	case "rva22u64":
 		return 22
 	}
-	Error = fmt.Errorf("invalid GORISCV64: must be rva20u64, rva22u64")
-	v := DefaultGORISCV64[len("rva"):]
+	Error = fmt.Errorf("invalid GORISCV64: must be rv64ima, rva20u64, rva22u64 and may optionally end in %q", rvcOpt)
+	v := strings.TrimSuffix(DefaultGORISCV64, rvcOpt)
+	if v == "rv64ima" {
+		return 0
+	}
+	v = v[len("rva"):]
 	i := strings.IndexFunc(v, func(r rune) bool {
 		return r < '0' || r > '9'
 	})
@@ -317,6 +329,12 @@ func goriscv64() int {
 	return year
 }
 
+// goriscv64rvc reports whether GORISCV64 ends in ",rvc", allowing the
+// assembler to emit compressed instructions.
+func goriscv64rvc() bool {
+	return strings.HasSuffix(envOr("GORISCV64", DefaultGORISCV64), rvcOpt)
+}
+
 type gowasmFeatures struct {
 	SatConv bool
 	SignExt bool
@@ -437,10 +455,18 @@ func gogoarchTags() []string {
 		}
 		return list
 	case "riscv64":
-		list := []string{GOARCH + "." + "rva20u64"}
+		var list []string
+		if GORISCV64 == 0 {
+			list = append(list, GOARCH+"."+"rv64ima")
+		} else {
+			list = append(list, GOARCH+"."+"rva20u64")
+		}
 		if GORISCV64 >= 22 {
 			list = append(list, GOARCH+"."+"rva22u64")
 		}
+		if GORISCV64RVC {
+			list = append(list, GOARCH+"."+"rvc")
+		}
 		return list
 	case "wasm":
 		var list []string
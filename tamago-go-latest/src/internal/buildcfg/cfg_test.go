@@ -24,6 +24,10 @@ func TestConfigFlags(t *testing.T) {
 		t.Errorf("Wrong parsing of GOAMD64=1")
 	}
 
+	os.Setenv("GORISCV64", "rv64ima")
+	if goriscv64() != 0 {
+		t.Errorf("Wrong parsing of RISCV64=rv64ima")
+	}
 	os.Setenv("GORISCV64", "rva20u64")
 	if goriscv64() != 20 {
 		t.Errorf("Wrong parsing of RISCV64=rva20u64")
@@ -32,6 +36,15 @@ func TestConfigFlags(t *testing.T) {
 	if goriscv64() != 22 {
 		t.Errorf("Wrong parsing of RISCV64=rva22u64")
 	}
+	os.Setenv("GORISCV64", "rv64ima,rvc")
+	if goriscv64() != 0 || !goriscv64rvc() {
+		t.Errorf("Wrong parsing of RISCV64=rv64ima,rvc")
+	}
+	Error = nil
+	os.Setenv("GORISCV64", "rva22u64,c")
+	if _ = goriscv64(); Error == nil {
+		t.Errorf("Wrong parsing of RISCV64=rva22u64,c")
+	}
 	Error = nil
 	os.Setenv("GORISCV64", "rva22")
 	if _ = goriscv64(); Error == nil {
@@ -148,6 +148,10 @@ func ParseGOEXPERIMENT(goos, goarch, goexp string) (*ExperimentFlags, error) {
 	if flags.RegabiArgs && !flags.RegabiWrappers {
 		return nil, fmt.Errorf("GOEXPERIMENT regabiargs requires regabiwrappers")
 	}
+	// Plain memory accesses are only atomic on a single hart.
+	if flags.SingleThreadAtomics && (goos != "tamago" || goarch != "riscv64") {
+		return nil, fmt.Errorf("GOEXPERIMENT singlethreadatomics requires GOOS=tamago GOARCH=riscv64")
+	}
 	return flags, nil
 }
 
@@ -0,0 +1,10 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build riscv64.rvc
+
+package goarch
+
+// Compressed instructions (GORISCV64=...,rvc) are 2 bytes long.
+const riscv64PCQuantum = 2
@@ -0,0 +1,8 @@
+// Code generated by mkconsts.go. DO NOT EDIT.
+
+//go:build goexperiment.singlethreadatomics
+
+package goexperiment
+
+const SingleThreadAtomics = true
+const SingleThreadAtomicsInt = 1
@@ -128,4 +128,10 @@ type Flags struct {
 
 	// Synctest enables the testing/synctest package.
 	Synctest bool
+
+	// SingleThreadAtomics lowers atomic operations to plain loads and
+	// stores, without fences. It is only valid on tamago/riscv64, which
+	// runs a single hart with no preemption nor interrupts touching Go
+	// memory.
+	SingleThreadAtomics bool
 }
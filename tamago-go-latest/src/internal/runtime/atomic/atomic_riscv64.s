@@ -27,6 +27,9 @@
 //     write.
 // aq is sufficient to guarantee this, so that's what we use here. (This jibes
 // with ARM, which uses dmb ishst.)
+//
+// With GOEXPERIMENT=singlethreadatomics (tamago only) there is a single hart
+// and no preemption, plain loads and stores are used instead.
 
 #include "textflag.h"
 
@@ -42,11 +45,17 @@ TEXT ·Cas(SB), NOSPLIT, $0-17
 	MOV	ptr+0(FP), A0
 	MOVW	old+8(FP), A1
 	MOVW	new+12(FP), A2
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A3
+	BNE	A3, A1, cas_fail
+	MOVW	A2, (A0)
+#else
 cas_again:
 	LRW	(A0), A3
 	BNE	A3, A1, cas_fail
 	SCW	A2, (A0), A4
 	BNE	A4, ZERO, cas_again
+#endif
 	MOV	$1, A0
 	MOVB	A0, ret+16(FP)
 	RET
@@ -60,11 +69,17 @@ TEXT ·Cas64(SB), NOSPLIT, $0-25
 	MOV	ptr+0(FP), A0
 	MOV	old+8(FP), A1
 	MOV	new+16(FP), A2
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A3
+	BNE	A3, A1, cas_fail
+	MOV	A2, (A0)
+#else
 cas_again:
 	LRD	(A0), A3
 	BNE	A3, A1, cas_fail
 	SCD	A2, (A0), A4
 	BNE	A4, ZERO, cas_again
+#endif
 	MOV	$1, A0
 	MOVB	A0, ret+24(FP)
 	RET
@@ -75,23 +90,35 @@ cas_fail:
 // func Load(ptr *uint32) uint32
 TEXT ·Load(SB),NOSPLIT|NOFRAME,$0-12
 	MOV	ptr+0(FP), A0
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A0
+#else
 	LRW	(A0), A0
+#endif
 	MOVW	A0, ret+8(FP)
 	RET
 
 // func Load8(ptr *uint8) uint8
 TEXT ·Load8(SB),NOSPLIT|NOFRAME,$0-9
 	MOV	ptr+0(FP), A0
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVBU	(A0), A1
+#else
 	FENCE
 	MOVBU	(A0), A1
 	FENCE
+#endif
 	MOVB	A1, ret+8(FP)
 	RET
 
 // func Load64(ptr *uint64) uint64
 TEXT ·Load64(SB),NOSPLIT|NOFRAME,$0-16
 	MOV	ptr+0(FP), A0
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A0
+#else
 	LRD	(A0), A0
+#endif
 	MOV	A0, ret+8(FP)
 	RET
 
@@ -99,23 +126,35 @@ TEXT ·Load64(SB),NOSPLIT|NOFRAME,$0-16
 TEXT ·Store(SB), NOSPLIT, $0-12
 	MOV	ptr+0(FP), A0
 	MOVW	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	A1, (A0)
+#else
 	AMOSWAPW A1, (A0), ZERO
+#endif
 	RET
 
 // func Store8(ptr *uint8, val uint8)
 TEXT ·Store8(SB), NOSPLIT, $0-9
 	MOV	ptr+0(FP), A0
 	MOVBU	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVB	A1, (A0)
+#else
 	FENCE
 	MOVB	A1, (A0)
 	FENCE
+#endif
 	RET
 
 // func Store64(ptr *uint64, val uint64)
 TEXT ·Store64(SB), NOSPLIT, $0-16
 	MOV	ptr+0(FP), A0
 	MOV	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	A1, (A0)
+#else
 	AMOSWAPD A1, (A0), ZERO
+#endif
 	RET
 
 TEXT ·Casp1(SB), NOSPLIT, $0-25
@@ -160,7 +199,14 @@ TEXT ·Xaddint32(SB),NOSPLIT,$0-20
 TEXT ·Xaddint64(SB),NOSPLIT,$0-24
 	MOV	ptr+0(FP), A0
 	MOV	delta+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A2
+	ADD	A2, A1, A3
+	MOV	A3, (A0)
+	MOV	A2, A0
+#else
 	AMOADDD A1, (A0), A0
+#endif
 	ADD	A0, A1, A0
 	MOVW	A0, ret+16(FP)
 	RET
@@ -195,7 +241,13 @@ TEXT ·StoreReluintptr(SB), NOSPLIT, $0-16
 TEXT ·Xchg(SB), NOSPLIT, $0-20
 	MOV	ptr+0(FP), A0
 	MOVW	new+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	MOVW	A1, (A0)
+	MOV	A2, A1
+#else
 	AMOSWAPW A1, (A0), A1
+#endif
 	MOVW	A1, ret+16(FP)
 	RET
 
@@ -203,7 +255,13 @@ TEXT ·Xchg(SB), NOSPLIT, $0-20
 TEXT ·Xchg64(SB), NOSPLIT, $0-24
 	MOV	ptr+0(FP), A0
 	MOV	new+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A2
+	MOV	A1, (A0)
+	MOV	A2, A1
+#else
 	AMOSWAPD A1, (A0), A1
+#endif
 	MOV	A1, ret+16(FP)
 	RET
 
@@ -215,7 +273,13 @@ TEXT ·Xchg64(SB), NOSPLIT, $0-24
 TEXT ·Xadd(SB), NOSPLIT, $0-20
 	MOV	ptr+0(FP), A0
 	MOVW	delta+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	ADD	A2, A1, A3
+	MOVW	A3, (A0)
+#else
 	AMOADDW A1, (A0), A2
+#endif
 	ADD	A2,A1,A0
 	MOVW	A0, ret+16(FP)
 	RET
@@ -224,7 +288,13 @@ TEXT ·Xadd(SB), NOSPLIT, $0-20
 TEXT ·Xadd64(SB), NOSPLIT, $0-24
 	MOV	ptr+0(FP), A0
 	MOV	delta+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A2
+	ADD	A2, A1, A3
+	MOV	A3, (A0)
+#else
 	AMOADDD A1, (A0), A2
+#endif
 	ADD	A2, A1, A0
 	MOV	A0, ret+16(FP)
 	RET
@@ -255,7 +325,13 @@ TEXT ·And8(SB), NOSPLIT, $0-9
 	XOR	$255, A1
 	SLL	A2, A1
 	XOR	$-1, A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	AND	A1, A2
+	MOVW	A2, (A0)
+#else
 	AMOANDW A1, (A0), ZERO
+#endif
 	RET
 
 // func Or8(ptr *uint8, val uint8)
@@ -266,28 +342,52 @@ TEXT ·Or8(SB), NOSPLIT, $0-9
 	AND	$-4, A0
 	SLL	$3, A2
 	SLL	A2, A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	OR	A1, A2
+	MOVW	A2, (A0)
+#else
 	AMOORW	A1, (A0), ZERO
+#endif
 	RET
 
 // func And(ptr *uint32, val uint32)
 TEXT ·And(SB), NOSPLIT, $0-12
 	MOV	ptr+0(FP), A0
 	MOVW	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	AND	A1, A2
+	MOVW	A2, (A0)
+#else
 	AMOANDW	A1, (A0), ZERO
+#endif
 	RET
 
 // func Or(ptr *uint32, val uint32)
 TEXT ·Or(SB), NOSPLIT, $0-12
 	MOV	ptr+0(FP), A0
 	MOVW	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	OR	A1, A2
+	MOVW	A2, (A0)
+#else
 	AMOORW	A1, (A0), ZERO
+#endif
 	RET
 
 // func Or32(ptr *uint32, val uint32) uint32
 TEXT ·Or32(SB), NOSPLIT, $0-20
 	MOV	ptr+0(FP), A0
 	MOVW	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	OR	A1, A2, A3
+	MOVW	A3, (A0)
+#else
 	AMOORW	A1, (A0), A2
+#endif
 	MOVW	A2, ret+16(FP)
 	RET
 
@@ -295,7 +395,13 @@ TEXT ·Or32(SB), NOSPLIT, $0-20
 TEXT ·And32(SB), NOSPLIT, $0-20
 	MOV	ptr+0(FP), A0
 	MOVW	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOVW	(A0), A2
+	AND	A1, A2, A3
+	MOVW	A3, (A0)
+#else
 	AMOANDW	A1, (A0), A2
+#endif
 	MOVW	A2, ret+16(FP)
 	RET
 
@@ -303,7 +409,13 @@ TEXT ·And32(SB), NOSPLIT, $0-20
 TEXT ·Or64(SB), NOSPLIT, $0-24
 	MOV	ptr+0(FP), A0
 	MOV	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A2
+	OR	A1, A2, A3
+	MOV	A3, (A0)
+#else
 	AMOORD	A1, (A0), A2
+#endif
 	MOV	A2, ret+16(FP)
 	RET
 
@@ -311,7 +423,13 @@ TEXT ·Or64(SB), NOSPLIT, $0-24
 TEXT ·And64(SB), NOSPLIT, $0-24
 	MOV	ptr+0(FP), A0
 	MOV	val+8(FP), A1
+#ifdef GOEXPERIMENT_singlethreadatomics
+	MOV	(A0), A2
+	AND	A1, A2, A3
+	MOV	A3, (A0)
+#else
 	AMOANDD	A1, (A0), A2
+#endif
 	MOV	A2, ret+16(FP)
 	RET
 
@@ -384,3 +384,90 @@ func TestStorepNoWB(t *testing.T) {
 		t.Error("Bad escape analysis of StorepNoWB")
 	}
 }
+
+// TestSequential checks the results of the operations on a single goroutine,
+// both when inlined as compiler intrinsics and when called through function
+// values, which always reach the assembly implementations. They must agree
+// under GOEXPERIMENT=singlethreadatomics, which replaces both with plain loads
+// and stores on tamago/riscv64.
+func TestSequential(t *testing.T) {
+	check := func(name string, got, want uint64) {
+		t.Helper()
+		if got != want {
+			t.Errorf("%s = %#x, want %#x", name, got, want)
+		}
+	}
+
+	var x32 uint32
+	var x64 uint64
+	var x8 uint8
+
+	cas, cas64 := atomic.Cas, atomic.Cas64
+	xchg, xchg64 := atomic.Xchg, atomic.Xchg64
+	xadd, xadd64 := atomic.Xadd, atomic.Xadd64
+	load, load64, load8 := atomic.Load, atomic.Load64, atomic.Load8
+	store, store64, store8 := atomic.Store, atomic.Store64, atomic.Store8
+	and32, or32 := atomic.And32, atomic.Or32
+	and64, or64 := atomic.And64, atomic.Or64
+
+	for _, indirect := range []bool{false, true} {
+		x32, x64, x8 = 0, 0, 0
+
+		if indirect {
+			store(&x32, 0xf0)
+			store64(&x64, 0xf0<<32)
+			store8(&x8, 0xf0)
+			check("Load", uint64(load(&x32)), 0xf0)
+			check("Load64", load64(&x64), 0xf0<<32)
+			check("Load8", uint64(load8(&x8)), 0xf0)
+
+			check("Cas(miss)", b2u(cas(&x32, 1, 2)), 0)
+			check("Cas", b2u(cas(&x32, 0xf0, 0xf1)), 1)
+			check("Cas64(miss)", b2u(cas64(&x64, 1, 2)), 0)
+			check("Cas64", b2u(cas64(&x64, 0xf0<<32, 0xf1<<32)), 1)
+
+			check("Xchg", uint64(xchg(&x32, 0xff)), 0xf1)
+			check("Xchg64", xchg64(&x64, 0xff<<32), 0xf1<<32)
+			check("Xadd", uint64(xadd(&x32, -0xf)), 0xf0)
+			check("Xadd64", xadd64(&x64, -0xf<<32), 0xf0<<32)
+
+			check("And32", uint64(and32(&x32, 0x3c)), 0xf0)
+			check("Or32", uint64(or32(&x32, 0x03)), 0x30)
+			check("And64", and64(&x64, 0x3c<<32), 0xf0<<32)
+			check("Or64", or64(&x64, 0x03<<32), 0x30<<32)
+		} else {
+			atomic.Store(&x32, 0xf0)
+			atomic.Store64(&x64, 0xf0<<32)
+			atomic.Store8(&x8, 0xf0)
+			check("Load", uint64(atomic.Load(&x32)), 0xf0)
+			check("Load64", atomic.Load64(&x64), 0xf0<<32)
+			check("Load8", uint64(atomic.Load8(&x8)), 0xf0)
+
+			check("Cas(miss)", b2u(atomic.Cas(&x32, 1, 2)), 0)
+			check("Cas", b2u(atomic.Cas(&x32, 0xf0, 0xf1)), 1)
+			check("Cas64(miss)", b2u(atomic.Cas64(&x64, 1, 2)), 0)
+			check("Cas64", b2u(atomic.Cas64(&x64, 0xf0<<32, 0xf1<<32)), 1)
+
+			check("Xchg", uint64(atomic.Xchg(&x32, 0xff)), 0xf1)
+			check("Xchg64", atomic.Xchg64(&x64, 0xff<<32), 0xf1<<32)
+			check("Xadd", uint64(atomic.Xadd(&x32, -0xf)), 0xf0)
+			check("Xadd64", atomic.Xadd64(&x64, -0xf<<32), 0xf0<<32)
+
+			check("And32", uint64(atomic.And32(&x32, 0x3c)), 0xf0)
+			check("Or32", uint64(atomic.Or32(&x32, 0x03)), 0x30)
+			check("And64", atomic.And64(&x64, 0x3c<<32), 0xf0<<32)
+			check("Or64", atomic.Or64(&x64, 0x03<<32), 0x30<<32)
+		}
+
+		check("x32", uint64(x32), 0x33)
+		check("x64", x64, 0x33<<32)
+		check("x8", uint64(x8), 0xf0)
+	}
+}
+
+func b2u(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build !riscv64.rv64ima
+
 #include "textflag.h"
 
 // RISC-V offered floating-point (FP) rounding by FP conversion instructions (FCVT)
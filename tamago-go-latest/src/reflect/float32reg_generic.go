@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build !ppc64 && !ppc64le && !riscv64
+//go:build !ppc64 && !ppc64le && (!riscv64 || riscv64.rv64ima)
 
 package reflect
 
@@ -322,7 +322,11 @@ TEXT runtime·mcall<ABIInternal>(SB), NOSPLIT|NOFRAME, $0-8
 // Smashes X31.
 TEXT gosave_systemstack_switch<>(SB),NOSPLIT|NOFRAME,$0
 	MOV	$runtime·systemstack_switch(SB), X31
+#ifdef GORISCV64_rvc
+	ADD	$6, X31	// get past prologue (SD and C.ADDI)
+#else
 	ADD	$8, X31	// get past prologue
+#endif
 	MOV	X31, (g_sched+gobuf_pc)(g)
 	MOV	X2, (g_sched+gobuf_sp)(g)
 	MOV	ZERO, (g_sched+gobuf_lr)(g)
@@ -698,6 +702,7 @@ TEXT ·checkASM(SB),NOSPLIT,$0-1
 	RET
 
 // spillArgs stores return values from registers to a *internal/abi.RegArgs in X25.
+// Under GORISCV64=rv64ima there are no floating point argument registers.
 TEXT ·spillArgs(SB),NOSPLIT,$0-0
 	MOV	X10, (0*8)(X25)
 	MOV	X11, (1*8)(X25)
@@ -715,6 +720,7 @@ TEXT ·spillArgs(SB),NOSPLIT,$0-0
 	MOV	X21, (13*8)(X25)
 	MOV	X22, (14*8)(X25)
 	MOV	X23, (15*8)(X25)
+#ifndef GORISCV64_rv64ima
 	MOVD	F10, (16*8)(X25)
 	MOVD	F11, (17*8)(X25)
 	MOVD	F12, (18*8)(X25)
@@ -731,6 +737,7 @@ TEXT ·spillArgs(SB),NOSPLIT,$0-0
 	MOVD	F21, (29*8)(X25)
 	MOVD	F22, (30*8)(X25)
 	MOVD	F23, (31*8)(X25)
+#endif
 	RET
 
 // unspillArgs loads args into registers from a *internal/abi.RegArgs in X25.
@@ -751,6 +758,7 @@ TEXT ·unspillArgs(SB),NOSPLIT,$0-0
 	MOV	(13*8)(X25), X21
 	MOV	(14*8)(X25), X22
 	MOV	(15*8)(X25), X23
+#ifndef GORISCV64_rv64ima
 	MOVD	(16*8)(X25), F10
 	MOVD	(17*8)(X25), F11
 	MOVD	(18*8)(X25), F12
@@ -767,6 +775,7 @@ TEXT ·unspillArgs(SB),NOSPLIT,$0-0
 	MOVD	(29*8)(X25), F21
 	MOVD	(30*8)(X25), F22
 	MOVD	(31*8)(X25), F23
+#endif
 	RET
 
 // gcWriteBarrier informs the GC about heap pointer writes.
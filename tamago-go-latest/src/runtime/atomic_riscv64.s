@@ -6,5 +6,7 @@
 
 // func publicationBarrier()
 TEXT ·publicationBarrier(SB),NOSPLIT|NOFRAME,$0-0
+#ifndef GOEXPERIMENT_singlethreadatomics
 	FENCE
+#endif
 	RET
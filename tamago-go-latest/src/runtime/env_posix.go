@@ -7,10 +7,6 @@ package runtime
 import "unsafe"
 
 func gogetenv(key string) string {
-	if GOOS == "tamago" {
-		return ""
-	}
-
 	env := environ()
 	if env == nil {
 		throw("getenv before env init")
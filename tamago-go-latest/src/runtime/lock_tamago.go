@@ -153,8 +153,33 @@ func checkTimeouts() {
 
 // beforeIdle gets called by the scheduler if no goroutine is awake.
 //
+// With a virtual clock (see Timejump) the clock is moved to the earliest
+// timer or note deadline, which makes its goroutine ready. Without any
+// deadline and with DetectDeadlock set, nothing can wake a goroutine anymore
+// and the runtime is terminated.
+//
 //go:yeswritebarrierrec
 func beforeIdle(now, pollUntil int64) (gp *g, otherReady bool) {
+	for n := allDeadlineNotes; n != nil; n = n.allnext {
+		if n.status == note_cleared && n.deadline != 0 && (pollUntil == 0 || n.deadline < pollUntil) {
+			pollUntil = n.deadline
+		}
+	}
+
+	if Timejump != nil && pollUntil != 0 {
+		if pollUntil > nanotime() {
+			Timejump(pollUntil)
+		}
+
+		checkTimeouts()
+		return nil, true
+	}
+
+	if DetectDeadlock && pollUntil == 0 {
+		deadlocked = true
+		fatal("all goroutines are asleep - deadlock!")
+	}
+
 	if Idle != nil {
 		Idle(pollUntil)
 	}
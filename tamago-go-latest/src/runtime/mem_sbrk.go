@@ -49,6 +49,10 @@ func (p memHdrPtr) ptr() *memHdr   { return (*memHdr)(unsafe.Pointer(p)) }
 func (p *memHdrPtr) set(x *memHdr) { *p = memHdrPtr(unsafe.Pointer(x)) }
 
 func memAlloc(n uintptr) unsafe.Pointer {
+	if gcDisabled() {
+		// Bump allocation, the free list is never filled.
+		return sbrk(n)
+	}
 	if p := memAllocNoGrow(n); p != nil {
 		return p
 	}
@@ -166,6 +170,10 @@ func sysAllocOS(n uintptr) unsafe.Pointer {
 }
 
 func sysFreeOS(v unsafe.Pointer, n uintptr) {
+	if gcDisabled() {
+		// Bump allocation, memory is never reused.
+		return
+	}
 	lock(&memlock)
 	if uintptr(v)+n == bloc {
 		// Address range being freed is at the end of memory,
@@ -240,7 +248,7 @@ func sysReserveAlignedSbrk(size, align uintptr) (unsafe.Pointer, uintptr) {
 	r := sbrk(p + size - bloc)
 	if r == nil {
 		p, size = 0, 0
-	} else if l := p - uintptr(r); l > 0 {
+	} else if l := p - uintptr(r); l > 0 && !gcDisabled() {
 		// Free the area we skipped over for alignment.
 		memFree(r, l)
 		memCheck()
@@ -13,6 +13,12 @@ func sbrk(n uintptr) unsafe.Pointer {
 	if bl+n > blocMax {
 		// Stop at stack top address
 		if bl+n > uintptr(g0.stack.lo) {
+			if gcDisabled() {
+				heapExhausted = true
+				print("runtime: out of memory: cannot allocate ", n, "-byte block (heap at ", hex(bl), ", stack at ", hex(g0.stack.lo), ", GC disabled)\n")
+				printOOM()
+				throw("out of memory")
+			}
 			return nil
 		} else {
 			memclrNoHeapPointers(unsafe.Pointer(bl), n)
@@ -22,3 +28,73 @@ func sbrk(n uintptr) unsafe.Pointer {
 	bloc += n
 	return unsafe.Pointer(bl)
 }
+
+// oomSites is the number of allocation sites reported by printOOM.
+const oomSites = 5
+
+// printOOM prints the heap state and, if memory profiling is enabled, the
+// allocation sites with the most bytes in use, once the heap could not be
+// grown any further. It must not allocate.
+func printOOM() {
+	start, end := MemRegion()
+
+	print("runtime: RAM [", hex(start), ", ", hex(end), "), heap high-water mark ", hex(blocMax), ", stack at ", hex(g0.stack.lo), "\n")
+
+	if gcDisabled() {
+		print("runtime: GC disabled, no memory was ever reclaimed\n")
+	} else {
+		print("runtime: live heap ", gcController.heapMarked, " bytes after GC #", memstats.numgc, "\n")
+	}
+
+	if MemProfileRate == 0 {
+		return
+	}
+
+	var top [oomSites]*bucket
+	var inuse [oomSites]uintptr
+
+	for b := (*bucket)(mbuckets.Load()); b != nil; b = b.allnext {
+		mp := b.mp()
+		c := mp.active
+
+		for i := range mp.future {
+			c.add(&mp.future[i])
+		}
+
+		n := c.alloc_bytes - c.free_bytes
+
+		for i := range top {
+			if top[i] == nil || n > inuse[i] {
+				copy(top[i+1:], top[i:])
+				copy(inuse[i+1:], inuse[i:])
+				top[i], inuse[i] = b, n
+				break
+			}
+		}
+	}
+
+	print("runtime: top allocation sites (sampled every ", MemProfileRate, " bytes):\n")
+
+	for i, b := range top {
+		if b == nil || inuse[i] == 0 {
+			break
+		}
+
+		print(inuse[i], " bytes in use\n")
+
+		for _, pc := range b.stk() {
+			f := findfunc(pc)
+
+			if !f.valid() {
+				continue
+			}
+
+			u, uf := newInlineUnwinder(f, pc-1)
+			file, line := u.fileLine(uf)
+
+			print("\t")
+			printFuncName(u.srcFunc(uf).name())
+			print("\n\t\t", file, ":", line, "\n")
+		}
+	}
+}
@@ -489,6 +489,10 @@ func GC() {
 	// GC may move ahead on its own. For example, when we block
 	// until mark termination N, we may wake up in cycle N+2.
 
+	if gcDisabled() {
+		return
+	}
+
 	// Wait until the current sweep termination, mark, and mark
 	// termination complete.
 	n := work.cycles.Load()
@@ -627,6 +631,10 @@ func (t gcTrigger) test() bool {
 // This may return without performing this transition in some cases,
 // such as when called on a system stack or with locks held.
 func gcStart(trigger gcTrigger) {
+	if gcDisabled() {
+		return
+	}
+
 	// Since this is called from malloc and malloc is called in
 	// the guts of a number of libraries that might be holding
 	// locks, don't attempt to start GC in non-preemptible or
@@ -1286,6 +1286,9 @@ func setGCPercent(in int32) (out int32) {
 }
 
 func readGOGC() int32 {
+	if gcDisabled() {
+		return -1
+	}
 	p := gogetenv("GOGC")
 	if p == "off" {
 		return -1
@@ -242,6 +242,10 @@ type mheap struct {
 
 var mheap_ mheap
 
+// heapExhausted is set once the heap failed to grow because the OS (or, on
+// tamago, the board RAM) could not provide more memory.
+var heapExhausted bool
+
 // A heapArena stores metadata for a heap arena. heapArenas are stored
 // outside of the Go heap and accessed via the mheap_.arenas index.
 type heapArena struct {
@@ -1500,6 +1504,8 @@ func (h *mheap) grow(npage uintptr) (uintptr, bool) {
 		if av == nil {
 			inUse := gcController.heapFree.load() + gcController.heapReleased.load() + gcController.heapInUse.load()
 			print("runtime: out of memory: cannot allocate ", ask, "-byte block (", inUse, " in use)\n")
+			printOOM()
+			heapExhausted = true
 			return 0, false
 		}
 
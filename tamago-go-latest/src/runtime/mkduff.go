@@ -262,6 +262,8 @@ func zeroRISCV64(w io.Writer) {
 	// ZERO: always zero
 	// X25: ptr to memory to be zeroed
 	// X25 is updated as a side effect.
+	// With GORISCV64=...,rvc each block is 6 bytes instead of 8,
+	// see duffOffset in cmd/compile/internal/riscv64.
 	fmt.Fprintln(w, "TEXT runtime·duffzero<ABIInternal>(SB), NOSPLIT|NOFRAME, $0-0")
 	for i := 0; i < 128; i++ {
 		fmt.Fprintln(w, "\tMOV\tZERO, (X25)")
@@ -274,6 +276,8 @@ func copyRISCV64(w io.Writer) {
 	// X24: ptr to source memory
 	// X25: ptr to destination memory
 	// X24 and X25 are updated as a side effect
+	// With GORISCV64=...,rvc each block is 12 bytes instead of 16,
+	// see duffOffset in cmd/compile/internal/riscv64.
 	fmt.Fprintln(w, "TEXT runtime·duffcopy<ABIInternal>(SB), NOSPLIT|NOFRAME, $0-0")
 	for i := 0; i < 128; i++ {
 		fmt.Fprintln(w, "\tMOV\t(X24), X31")
@@ -556,20 +556,28 @@ func genRISCV64() {
 		l.add("MOV", reg, 8)
 	}
 
-	// Add floating point registers (F0-F31).
+	// Add floating point registers (F0-F31), which do not exist under
+	// GORISCV64=rv64ima.
+	var lfp = layout{sp: "X2", stack: l.stack}
 	for i := 0; i <= 31; i++ {
 		reg := fmt.Sprintf("F%d", i)
-		l.add("MOVD", reg, 8)
+		lfp.add("MOVD", reg, 8)
 	}
 
-	p("MOV X1, -%d(X2)", l.stack)
-	p("SUB $%d, X2", l.stack)
+	p("MOV X1, -%d(X2)", lfp.stack)
+	p("SUB $%d, X2", lfp.stack)
 	l.save()
+	p("#ifndef GORISCV64_rv64ima")
+	lfp.save()
+	p("#endif")
 	p("CALL ·asyncPreempt2(SB)")
+	p("#ifndef GORISCV64_rv64ima")
+	lfp.restore()
+	p("#endif")
 	l.restore()
-	p("MOV %d(X2), X1", l.stack)
+	p("MOV %d(X2), X1", lfp.stack)
 	p("MOV (X2), X31")
-	p("ADD $%d, X2", l.stack+8)
+	p("ADD $%d, X2", lfp.stack+8)
 	p("JMP (X31)")
 }
 
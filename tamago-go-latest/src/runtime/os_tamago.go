@@ -7,6 +7,7 @@
 package runtime
 
 import (
+	"internal/bytealg"
 	"internal/runtime/atomic"
 	"unsafe"
 )
@@ -17,6 +18,23 @@ var testBinary string
 // Bloc allows to override the heap memory start address
 var Bloc uintptr
 
+// buildEnv holds the environment set at link time (see cmd/link -env), as NUL
+// separated key=value definitions.
+var buildEnv string
+
+// NoGC disables the garbage collector when set by the linked application: the
+// GC is never started, not even by GC(), and the heap becomes a bump
+// allocator which never reuses memory. It is meant for short-lived programs
+// whose allocations fit in RAM, where collection is pure overhead.
+var NoGC bool
+
+// hwinit0 may set argc and argv, laid out as on Unix (argument pointers, nil,
+// environment pointers, nil), to provide command-line arguments and
+// environment variables.
+//
+//go:linkname argc
+//go:linkname argv
+
 // the following functions must be provided externally
 func hwinit0()
 func hwinit1()
@@ -51,7 +69,6 @@ type mOS struct{}
 type sigset struct{}
 type gsignalStack struct{}
 
-func goenvs()                        {}
 func sigsave(p *sigset)              {}
 func msigrestore(sigmask sigset)     {}
 func clearSignalHandlers()           {}
@@ -72,11 +89,68 @@ func newosproc(mp *m) {
 	throw("newosproc: not implemented")
 }
 
+// defaultG0StackSize is the g0 stack size used when g0StackSize is not
+// provided.
+const defaultG0StackSize = 64 << 10
+
 // Called to initialize a new m (including the bootstrap m).
 // Called on the parent thread (main thread in case of bootstrap), can allocate memory.
 func mpreinit(mp *m) {
-	mp.gsignal = malg(32 * 1024)
-	mp.gsignal.m = mp
+	// there are no signals, m.gsignal is left nil
+}
+
+// goenvs sets the process environment to the one optionally provided by the
+// linked application through argv (see hwinit0), followed by the link time
+// one.
+func goenvs() {
+	n := int32(0)
+	for argc > 0 && argv_index(argv, argc+1+n) != nil {
+		n++
+	}
+
+	envs = make([]string, 0, int(n)+bytealg.CountString(buildEnv, 0)+1)
+
+	for i := int32(0); i < n; i++ {
+		envs = append(envs, gostring(argv_index(argv, argc+1+i)))
+	}
+
+	for s := buildEnv; s != ""; {
+		var kv string
+		kv, s = cutEnv(s)
+		envs = append(envs, kv)
+	}
+}
+
+// cutEnv returns the first entry of the NUL separated environment s and the
+// remaining ones, it does not allocate.
+func cutEnv(s string) (kv, rest string) {
+	if i := bytealg.IndexByteString(s, 0); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+
+	return s, ""
+}
+
+// buildGetenv returns the value of key in the link time environment, unlike
+// gogetenv it can be used before goenvs (e.g. by boards in initRNG).
+//
+//go:linkname buildGetenv
+func buildGetenv(key string) string {
+	for s := buildEnv; s != ""; {
+		var kv string
+		kv, s = cutEnv(s)
+
+		if len(kv) > len(key) && kv[len(key)] == '=' && kv[:len(key)] == key {
+			return kv[len(key)+1:]
+		}
+	}
+
+	return ""
+}
+
+// gcDisabled reports whether the garbage collector is disabled (see NoGC).
+func gcDisabled() bool {
+	return NoGC
 }
 
 func osinit() {
@@ -89,6 +163,8 @@ func osinit() {
 	} else {
 		initBloc()
 	}
+
+	blocStart = bloc
 }
 
 func readRandom(r []byte) int {
@@ -158,6 +234,12 @@ func walltime() (sec int64, nsec int32) {
 //go:nosplit
 func usleep(us uint32) {
 	wake := nanotime() + int64(us)*1000
+
+	if Timejump != nil {
+		Timejump(wake)
+		return
+	}
+
 	for nanotime() < wake {
 	}
 }
@@ -175,7 +257,43 @@ var Exit func(int32)
 // implementation for CPU idle time management (see beforeIdle()).
 var Idle func(until int64)
 
+// Timejump can be provided externally by the linked application, which
+// implements a virtual clock, to move nanotime() forward to until. It is
+// invoked when all goroutines are sleeping, with the earliest timer
+// expiration, so that sleeping costs no CPU time (see beforeIdle()), and by
+// usleep.
+//
+// Timejump may be called without a P and must not allocate nor grow the stack.
+var Timejump func(until int64)
+
+// DetectDeadlock can be set by the linked application, when nothing external
+// (e.g. an interrupt) can wake a goroutine, to terminate the runtime with a
+// fatal error when all goroutines are asleep without any pending timer,
+// rather than idling forever (see beforeIdle()).
+var DetectDeadlock bool
+
+// deadlocked is set when DetectDeadlock terminated the runtime.
+var deadlocked bool
+
+// Deadlocked reports whether the runtime is terminating because all
+// goroutines are asleep (see DetectDeadlock), it allows Exit implementations
+// to tell deadlocks apart from other fatal errors.
+func Deadlocked() bool {
+	return deadlocked
+}
+
+// OutOfMemory reports whether the heap could not be grown any further, it
+// allows Exit implementations to tell allocation failures apart from other
+// fatal errors (which all exit with code 2).
+func OutOfMemory() bool {
+	return heapExhausted
+}
+
 func exit(code int32) {
+	if ExitSummary {
+		printSummary(code)
+	}
+
 	if Exit != nil {
 		Exit(code)
 	}
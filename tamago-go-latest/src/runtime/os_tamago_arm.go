@@ -11,6 +11,10 @@ var ramStart uint32
 var ramSize uint32
 var ramStackOffset uint32
 
+// g0StackSize can be optionally provided externally to set the size of the
+// g0 stack carved out of the boot stack (default defaultG0StackSize).
+var g0StackSize uint32
+
 // CallOnG0 calls a function (func(off int)) on g0 stack.
 //
 // The function is meant to be invoked within Go assembly and its arguments
@@ -11,6 +11,10 @@ var ramStart uint64
 var ramSize uint64
 var ramStackOffset uint64
 
+// g0StackSize can be optionally provided externally to set the size of the
+// g0 stack carved out of the boot stack (default defaultG0StackSize).
+var g0StackSize uint64
+
 // defined in asm_riscv64.s
 func cputicks() int64
 
@@ -1096,6 +1096,7 @@ func throw(s string) {
 		print("fatal error: ")
 		printindented(s) // logically printpanicval(s), but avoids convTstring write barrier
 		print("\n")
+		recordFatalString(s)
 	})
 
 	fatalthrow(throwTypeRuntime)
@@ -1118,6 +1119,7 @@ func fatal(s string) {
 		print("fatal error: ")
 		printindented(s) // logically printpanicval(s), but avoids convTstring write barrier
 		print("\n")
+		recordFatalString(s)
 	})
 
 	fatalthrow(throwTypeUser)
@@ -1316,6 +1318,7 @@ func fatalpanic(msgs *_panic) {
 			runningPanicDefers.Add(-1)
 
 			printpanics(msgs)
+			recordFatalPanic(msgs)
 		}
 
 		docrash = dopanic_m(gp, pc, sp)
@@ -1401,6 +1404,8 @@ var deadlock mutex
 // gp is the crashing g running on this M, but may be a user G, while getg() is
 // always g0.
 func dopanic_m(gp *g, pc, sp uintptr) bool {
+	recordFatalTraceback(gp, pc, sp)
+
 	if gp.sig != 0 {
 		signame := signame(gp.sig)
 		if signame != "" {
@@ -31,6 +31,7 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOV X28, 184(X2)
 	MOV X29, 192(X2)
 	MOV X30, 200(X2)
+	#ifndef GORISCV64_rv64ima
 	MOVD F0, 208(X2)
 	MOVD F1, 216(X2)
 	MOVD F2, 224(X2)
@@ -63,7 +64,9 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVD F29, 440(X2)
 	MOVD F30, 448(X2)
 	MOVD F31, 456(X2)
+	#endif
 	CALL ·asyncPreempt2(SB)
+	#ifndef GORISCV64_rv64ima
 	MOVD 456(X2), F31
 	MOVD 448(X2), F30
 	MOVD 440(X2), F29
@@ -96,6 +99,7 @@ TEXT ·asyncPreempt(SB),NOSPLIT|NOFRAME,$0-0
 	MOVD 224(X2), F2
 	MOVD 216(X2), F1
 	MOVD 208(X2), F0
+	#endif
 	MOV 200(X2), X30
 	MOV 192(X2), X29
 	MOV 184(X2), X28
@@ -567,6 +567,16 @@ func badmorestackg0() {
 	}
 
 	g := getg()
+
+	if GOOS == "tamago" && gcrash.stack.hi == 0 {
+		// The crash stack is allocated by schedinit, before then report
+		// the overflow using the stack guard slack reserved at boot.
+		g.stackguard0 = g.stack.lo
+		g.stackguard1 = g.stack.lo
+		writeErrStr("fatal: morestack on g0 during boot, g0 stack too small\n")
+		exit(2)
+	}
+
 	switchToCrashStack(func() {
 		print("runtime: morestack on g0, stack [", hex(g.stack.lo), " ", hex(g.stack.hi), "], sp=", hex(g.sched.sp), ", called from\n")
 		g.m.traceback = 2 // include pc and sp in stack trace
@@ -765,12 +775,12 @@ func getGodebugEarly() string {
 	const prefix = "GODEBUG="
 	var env string
 	switch GOOS {
-	case "aix", "darwin", "ios", "dragonfly", "freebsd", "netbsd", "openbsd", "illumos", "solaris", "linux":
+	case "aix", "darwin", "ios", "dragonfly", "freebsd", "netbsd", "openbsd", "illumos", "solaris", "linux", "tamago":
 		// Similar to goenv_unix but extracts the environment value for
 		// GODEBUG directly.
 		// TODO(moehrmann): remove when general goenvs() can be called before cpuinit()
 		n := int32(0)
-		for argv_index(argv, argc+1+n) != nil {
+		for argc > 0 && argv_index(argv, argc+1+n) != nil {
 			n++
 		}
 
@@ -779,10 +789,14 @@ func getGodebugEarly() string {
 			s := unsafe.String(p, findnull(p))
 
 			if stringslite.HasPrefix(s, prefix) {
-				env = gostring(p)[len(prefix):]
-				break
+				return gostring(p)[len(prefix):]
 			}
 		}
+
+		if GOOS == "tamago" {
+			// argv environment is optional, see goenvs
+			env = buildGetenv("GODEBUG")
+		}
 	}
 	return env
 }
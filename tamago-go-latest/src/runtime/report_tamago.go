@@ -0,0 +1,199 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build tamago
+
+package runtime
+
+import (
+	"unsafe"
+)
+
+const (
+	// FatalFrames is the maximum number of stack frames recorded in a
+	// FatalError.
+	FatalFrames = 8
+	// FatalMessageSize is the maximum length of the message recorded in a
+	// FatalError, longer messages are truncated.
+	FatalMessageSize = 256
+)
+
+// FatalError describes the unrecovered panic or fatal runtime error which is
+// terminating the runtime.
+//
+// It is filled without allocating or using write barriers, so that it remains
+// available to Exit implementations once the world is frozen.
+type FatalError struct {
+	// Throw is true for fatal runtime errors (see throw, fatal) and false
+	// for unrecovered panics.
+	Throw bool
+	// PC is the program counter of the faulting frame.
+	PC uintptr
+	// Goid is the identifier of the faulting goroutine.
+	Goid uint64
+
+	msg  [FatalMessageSize]byte
+	nmsg int
+	pcs  [FatalFrames]uintptr
+	npcs int
+}
+
+var fatalError FatalError
+var fatalErrorSet bool
+
+// LastFatalError returns the report of the unrecovered panic or fatal runtime
+// error terminating the runtime, or nil if there is none. It is meant to be
+// used by Exit implementations.
+func LastFatalError() *FatalError {
+	if !fatalErrorSet {
+		return nil
+	}
+
+	return &fatalError
+}
+
+// Message returns the panic value or fatal error message.
+func (e *FatalError) Message() string {
+	return unsafe.String(&e.msg[0], e.nmsg)
+}
+
+// Frames returns the number of recorded stack frames.
+func (e *FatalError) Frames() int {
+	return e.npcs
+}
+
+// Frame symbolizes the i-th recorded stack frame, starting from the faulting
+// one.
+func (e *FatalError) Frame(i int) (pc uintptr, function string, file string, line int) {
+	pc = e.pcs[i]
+
+	f := findfunc(pc)
+
+	if !f.valid() {
+		return
+	}
+
+	iu, uf := newInlineUnwinder(f, pc)
+	function = funcNameForPrint(iu.srcFunc(uf).name())
+	file, line = iu.fileLine(uf)
+
+	return
+}
+
+func (e *FatalError) appendMessage(s string) {
+	e.nmsg += copy(e.msg[e.nmsg:], s)
+}
+
+// recordFatalString records the message of a fatal runtime error.
+func recordFatalString(s string) {
+	if fatalErrorSet {
+		return
+	}
+
+	fatalErrorSet = true
+	fatalError.Throw = true
+	fatalError.appendMessage(s)
+}
+
+// recordFatalPanic records the value of an unrecovered panic, error and
+// stringer values have already been converted by preprintpanics.
+func recordFatalPanic(p *_panic) {
+	if fatalErrorSet || p == nil || p.goexit {
+		return
+	}
+
+	fatalErrorSet = true
+
+	var buf [20]byte
+
+	switch v := p.arg.(type) {
+	case nil:
+		fatalError.appendMessage("nil")
+	case string:
+		fatalError.appendMessage(v)
+	case bool:
+		if v {
+			fatalError.appendMessage("true")
+		} else {
+			fatalError.appendMessage("false")
+		}
+	case int:
+		fatalError.appendInt(int64(v), buf[:])
+	case int8:
+		fatalError.appendInt(int64(v), buf[:])
+	case int16:
+		fatalError.appendInt(int64(v), buf[:])
+	case int32:
+		fatalError.appendInt(int64(v), buf[:])
+	case int64:
+		fatalError.appendInt(v, buf[:])
+	case uint:
+		fatalError.appendUint(uint64(v), buf[:])
+	case uint8:
+		fatalError.appendUint(uint64(v), buf[:])
+	case uint16:
+		fatalError.appendUint(uint64(v), buf[:])
+	case uint32:
+		fatalError.appendUint(uint64(v), buf[:])
+	case uint64:
+		fatalError.appendUint(v, buf[:])
+	case uintptr:
+		fatalError.appendUint(uint64(v), buf[:])
+	default:
+		fatalError.appendMessage("(")
+		fatalError.appendMessage(toRType(efaceOf(&p.arg)._type).string())
+		fatalError.appendMessage(")")
+	}
+}
+
+func (e *FatalError) appendUint(v uint64, buf []byte) {
+	b := itoa(buf, v)
+	e.appendMessage(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+func (e *FatalError) appendInt(v int64, buf []byte) {
+	if v < 0 {
+		e.appendMessage("-")
+		v = -v
+	}
+
+	e.appendUint(uint64(v), buf)
+}
+
+// recordFatalTraceback records the faulting goroutine and the frames which
+// would be shown by its traceback.
+func recordFatalTraceback(gp *g, pc, sp uintptr) {
+	if !fatalErrorSet || fatalError.npcs != 0 {
+		return
+	}
+
+	if gp == gp.m.g0 && gp.m.curg != nil {
+		fatalError.Goid = gp.m.curg.goid
+	} else {
+		fatalError.Goid = gp.goid
+	}
+
+	fatalError.PC = pc
+
+	var u unwinder
+
+	for u.initAt(pc, sp, 0, gp, unwindSilentErrors); u.valid() && fatalError.npcs < FatalFrames; u.next() {
+		f := u.frame.fn
+		iu, uf := newInlineUnwinder(f, u.symPC())
+		sf := iu.srcFunc(uf)
+		callee := u.calleeFuncID
+		u.calleeFuncID = sf.funcID
+
+		if !showframe(sf, gp, fatalError.npcs == 0, callee) {
+			continue
+		}
+
+		if fatalError.npcs == 0 {
+			fatalError.PC = u.symPC()
+		}
+
+		fatalError.pcs[fatalError.npcs] = u.symPC()
+		fatalError.npcs++
+	}
+}
@@ -8,6 +8,21 @@
 
 // entry point for M privilege level instances
 TEXT _rt0_riscv64_tamago(SB),NOSPLIT|NOFRAME,$0
+	// copy initialized data from its load address when linked elsewhere
+	// (-D), before anything reads it
+	MOV	$runtime·dataload(SB), T0
+	MOV	$runtime·datastart(SB), T1
+	MOV	$runtime·bss(SB), T2
+	BEQ	T0, T1, start
+copy:
+	BGEU	T1, T2, start
+	MOV	(T0), T3
+	MOV	T3, (T1)
+	ADD	$8, T0
+	ADD	$8, T1
+	JMP	copy
+
+start:
 	MOV	runtime·testBinary(SB), T0
 	BGT	T0, ZERO, testing
 
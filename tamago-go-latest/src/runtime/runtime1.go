@@ -73,7 +73,8 @@ func goargs() {
 	if GOOS == "windows" {
 		return
 	}
-	if GOOS == "tamago" {
+	if GOOS == "tamago" && argc == 0 {
+		// no arguments provided by the linked application
 		argslice = []string{"tamago"}
 		return
 	}
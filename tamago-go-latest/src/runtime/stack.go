@@ -1107,6 +1107,10 @@ func newstack() {
 		throw("stack overflow")
 	}
 
+	if GOOS == "tamago" && newsize > stackHighWater {
+		stackHighWater = newsize
+	}
+
 	// The goroutine must be executing in order to call newstack,
 	// so it must be Grunning (or Gscanrunning).
 	casgstatus(gp, _Grunning, _Gcopystack)
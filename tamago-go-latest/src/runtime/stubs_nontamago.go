@@ -7,3 +7,13 @@
 package runtime
 
 var ramSize uint32
+var stackHighWater uintptr
+
+func gcDisabled() bool              { return false }
+func buildGetenv(key string) string { return "" }
+
+func printOOM() {}
+
+func recordFatalString(s string)                 {}
+func recordFatalPanic(p *_panic)                 {}
+func recordFatalTraceback(gp *g, pc, sp uintptr) {}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build tamago
+
+package runtime
+
+// ExitSummary can be set by the linked application to print a summary of the
+// resources used by the runtime on the console before exit, as a single line
+// of space separated key=value pairs:
+//
+//	runtime: summary v=1 exit=0 total_alloc=... heap_highwater=...
+//
+// The v key is the format version, keys are only ever added within a
+// version. Sizes are in bytes and durations in nanoseconds.
+var ExitSummary bool
+
+// blocStart is the heap start address, see osinit.
+var blocStart uintptr
+
+// stackHighWater is the largest goroutine stack size reached, see newstack.
+var stackHighWater uintptr
+
+// printSummary prints the resource summary, it does not allocate as it can be
+// invoked on fatal errors.
+func printSummary(code int32) {
+	var stats heapStatsDelta
+	memstats.heapStats.unsafeRead(&stats)
+
+	totalAlloc := stats.largeAlloc
+	mallocs := stats.largeAllocCount + stats.tinyAllocCount
+	frees := stats.largeFreeCount + stats.tinyAllocCount
+
+	for i := range stats.smallAllocCount {
+		totalAlloc += stats.smallAllocCount[i] * uint64(class_to_size[i])
+		mallocs += stats.smallAllocCount[i]
+		frees += stats.smallFreeCount[i]
+	}
+
+	// goroutine ids are handed out to each P in batches
+	created := sched.goidgen.Load()
+
+	for _, pp := range allp {
+		created -= pp.goidcacheend - pp.goidcache
+	}
+
+	stackMax := max(stackHighWater, uintptr(startingStackSize))
+
+	print("runtime: summary v=1",
+		" exit=", code,
+		" total_alloc=", totalAlloc,
+		" mallocs=", mallocs,
+		" frees=", frees,
+		" heap_live=", gcController.heapLive.Load(),
+		" heap_marked=", gcController.heapMarked,
+		" heap_inuse=", gcController.heapInUse.load(),
+		" heap_highwater=", blocMax-blocStart,
+		" num_gc=", memstats.numgc,
+		" gc_pause_total=", memstats.pause_total_ns,
+		" goroutines_created=", created,
+		" goroutines_live=", gcount(),
+		" stack_inuse=", stats.inStacks,
+		" stack_max=", stackMax,
+		"\n")
+}
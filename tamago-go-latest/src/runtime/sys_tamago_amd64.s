@@ -17,11 +17,19 @@
 TEXT runtime·rt0_amd64_tamago(SB),NOSPLIT|NOFRAME|TOPFRAME,$0
 	// create istack out of the bootstack
 	MOVQ	$runtime·g0(SB), DI
-	LEAQ	(-64*1024)(SP), AX
-	MOVQ	AX, g_stackguard0(DI)
-	MOVQ	AX, g_stackguard1(DI)
+	MOVQ	runtime·g0StackSize(SB), BX
+	CMPQ	BX, $0
+	JNE	istack
+	MOVQ	$const_defaultG0StackSize, BX
+istack:
+	MOVQ	SP, AX
+	SUBQ	BX, AX
 	MOVQ	AX, (g_stack+stack_lo)(DI)
 	MOVQ	SP, (g_stack+stack_hi)(DI)
+	// keep nosplit frames within the istack, rather than on the heap below
+	ADDQ	$const_stackGuard, AX
+	MOVQ	AX, g_stackguard0(DI)
+	MOVQ	AX, g_stackguard1(DI)
 
 	// find out information about the processor we're on
 	MOVL	$0, AX
@@ -23,12 +23,17 @@ TEXT runtime·rt0_arm_tamago(SB),NOSPLIT|NOFRAME,$0
 	// save g->m = m0
 	MOVW	R8, g_m(g)
 
-	// create 64kB istack out of the bootstack
-	MOVW	$(-64*1024)(R13), R0
-	MOVW	R0, g_stackguard0(g)
-	MOVW	R0, g_stackguard1(g)
+	// create istack out of the bootstack
+	MOVW	runtime·g0StackSize(SB), R1
+	CMP	$0, R1
+	MOVW.EQ	$const_defaultG0StackSize, R1
+	SUB	R1, R13, R0
 	MOVW	R0, (g_stack+stack_lo)(g)
 	MOVW	R13, (g_stack+stack_hi)(g)
+	// keep nosplit frames within the istack, rather than on the heap below
+	ADD	$const_stackGuard, R0
+	MOVW	R0, g_stackguard0(g)
+	MOVW	R0, g_stackguard1(g)
 
 	BL	runtime·emptyfunc(SB)	// fault if stack check is wrong
 	BL	runtime·hwinit0(SB)
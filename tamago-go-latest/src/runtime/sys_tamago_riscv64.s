@@ -13,12 +13,17 @@
 TEXT runtime·rt0_riscv64_tamago(SB),NOSPLIT|NOFRAME,$0
 	// create istack out of the bootstack
 	MOV	$runtime·g0(SB), g
-	MOV	$(-64*1024), T0
-	ADD	T0, X2, T1
-	MOV	T1, g_stackguard0(g)
-	MOV	T1, g_stackguard1(g)
+	MOV	runtime·g0StackSize(SB), T0
+	BNE	T0, ZERO, istack
+	MOV	$const_defaultG0StackSize, T0
+istack:
+	SUB	T0, X2, T1
 	MOV	T1, (g_stack+stack_lo)(g)
 	MOV	X2, (g_stack+stack_hi)(g)
+	// keep nosplit frames within the istack, rather than on the heap below
+	ADD	$const_stackGuard, T1
+	MOV	T1, g_stackguard0(g)
+	MOV	T1, g_stackguard1(g)
 
 	// set the per-goroutine and per-mach "registers"
 	MOV	$runtime·m0(SB), T0
@@ -30,9 +35,6 @@ TEXT runtime·rt0_riscv64_tamago(SB),NOSPLIT|NOFRAME,$0
 
 	CALL	runtime·hwinit0(SB)
 	CALL	runtime·check(SB)
-
-	// args are already prepared
-	CALL	runtime·args(SB)
 	CALL	runtime·osinit(SB)
 	CALL	runtime·schedinit(SB)
 	CALL	runtime·hwinit1(SB)
@@ -677,8 +677,8 @@ func Short() bool {
 		panic("testing: Short called before Parse")
 	}
 
-	if runtime.GOOS == "tamago" {
-		// flag cannot be passed due to lack of os.Args support
+	if runtime.GOOS == "tamago" && len(os.Args) <= 1 {
+		// flags are only available when os.Args is provided by the board
 		return true
 	}
 
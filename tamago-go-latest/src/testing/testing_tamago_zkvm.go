@@ -0,0 +1,195 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build tamago && riscv64 && zkvm_testing
+
+package testing
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Test binaries run under the ZisK zkVM emulator, with go test -exec and the
+// go_tamago_zisk_exec wrapper (see tamaboards/zkvm/cmd), cannot use the Linux
+// syscalls of the default testing board:
+//
+//   - the zkvm build tag leaves the runtime hooks to the tamaboards/zkvm
+//     board, for test binaries of packages importing it
+//   - the zkvm_testing build tag selects the minimal ZisK testing board
+//     below, for any other package
+//
+// The memory map matches the tamaboards/zkvm board (see its mem.go), test
+// binaries must be linked accordingly (-ldflags with zkvm.LinkFlags()).
+//
+// Test binaries enter the runtime through its testing path, which maps RAM
+// with an mmap ecall, ZisK ignores any ecall other than exit.
+const (
+	zkvmInputAddr = 0x90000000
+	zkvmMaxInput  = 0x2000
+	zkvmUARTAddr  = 0xa0000200
+
+	// data and bss are linked at the start of the runtime RAM, the heap
+	// follows them
+	zkvmRAMStart  = 0xa0020000
+	zkvmRAMEnd    = 0xc0000000
+	zkvmDataSize  = 0x1000000
+	zkvmHeapStart = zkvmRAMStart + zkvmDataSize
+
+	// zkvmArgsMagic identifies the argument header at the start of the
+	// input, see tamaboards/zkvm/argsheader.go
+	zkvmArgsMagic = 0x0001_5347_5241_4f47
+	zkvmMaxArgs   = 64
+
+	// zkvmExitStr precedes the exit status, printed on the console before
+	// exiting as the emulator does not report it
+	zkvmExitStr = "\nzisk_exitcode="
+)
+
+//go:linkname ramStart runtime.ramStart
+var ramStart uint64 = zkvmRAMStart
+
+//go:linkname ramSize runtime.ramSize
+var ramSize uint64 = zkvmRAMEnd - zkvmRAMStart
+
+//go:linkname ramStackOffset runtime.ramStackOffset
+var ramStackOffset uint64 = 0x100000
+
+//go:linkname argc runtime.argc
+var argc int32
+
+//go:linkname argv runtime.argv
+var argv **byte
+
+var argsTable [zkvmMaxArgs + 2]*byte
+
+// defined in testing_tamago_zkvm.s
+func sys_exit(code int32)
+
+// the emulator has no clock, time advances on each read and jumps to the
+// next timer when all goroutines sleep
+var clock int64
+
+//go:linkname nanotime1 runtime.nanotime1
+func nanotime1() int64 {
+	clock += 1000
+	return clock
+}
+
+//go:nosplit
+func timejump(until int64) {
+	if until > clock {
+		clock = until
+	}
+}
+
+// random data is deterministic (splitmix64 with a fixed seed), so that test
+// runs are reproducible
+var rngState uint64
+
+//go:linkname initRNG runtime.initRNG
+func initRNG() {}
+
+//go:linkname getRandomData runtime.getRandomData
+func getRandomData(b []byte) {
+	for i := range b {
+		rngState += 0x9e3779b97f4a7c15
+		z := rngState
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		b[i] = byte(z ^ z>>31)
+	}
+}
+
+//go:linkname printk runtime.printk
+func printk(c byte) {
+	*(*byte)(unsafe.Pointer(uintptr(zkvmUARTAddr))) = c
+}
+
+// exit reports the exit status on the console, followed by a new line, and
+// terminates the emulator.
+func exit(code int32) {
+	var buf [16]byte
+	i := len(buf) - 1
+	buf[i] = '\n'
+	n := uint32(code)
+	if code < 0 {
+		n = -n
+	}
+	for ; ; n /= 10 {
+		i--
+		buf[i] = byte('0' + n%10)
+		if n < 10 {
+			break
+		}
+	}
+	if code < 0 {
+		i--
+		buf[i] = '-'
+	}
+	for j := 0; j < len(zkvmExitStr); j++ {
+		printk(zkvmExitStr[j])
+	}
+	for _, c := range buf[i:] {
+		printk(c)
+	}
+	sys_exit(code)
+}
+
+// args passes the optional argument header of the input, carrying the test
+// flags, to the runtime as argc/argv.
+func args() {
+	le := func(b []byte, n int) (v uint64) {
+		for i := n - 1; i >= 0; i-- {
+			v = v<<8 | uint64(b[i])
+		}
+		return
+	}
+
+	n := *(*uint64)(unsafe.Pointer(uintptr(zkvmInputAddr + 8)))
+	if n < 16 || n > zkvmMaxInput-16 {
+		return
+	}
+	b := unsafe.Slice((*byte)(unsafe.Pointer(uintptr(zkvmInputAddr+16))), n)
+	if le(b, 8) != zkvmArgsMagic {
+		return
+	}
+	c, envc := int(le(b[8:], 4)), int(le(b[12:], 4))
+	if c == 0 || c > zkvmMaxArgs || envc > zkvmMaxArgs-c {
+		return
+	}
+
+	// arguments, nil, environment, nil (see runtime.goenvs)
+	off := 16
+	for i := 0; i < c+envc; i++ {
+		j := i
+		if i >= c {
+			j++
+		}
+		argsTable[j] = &b[off]
+		for off < len(b) && b[off] != 0 {
+			off++
+		}
+		if off == len(b) {
+			return
+		}
+		off++
+	}
+	argc = int32(c)
+	argv = &argsTable[0]
+}
+
+//go:linkname hwinit0 runtime.hwinit0
+func hwinit0() {
+	runtime.Bloc = zkvmHeapStart
+	runtime.Exit = exit
+	args()
+}
+
+//go:linkname hwinit1 runtime.hwinit1
+func hwinit1() {
+	runtime.Timejump = timejump
+	// nothing external can wake a goroutine
+	runtime.DetectDeadlock = true
+}
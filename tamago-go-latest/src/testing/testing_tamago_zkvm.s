@@ -0,0 +1,19 @@
+// Copyright 2025 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build tamago && riscv64 && zkvm_testing
+
+#include "textflag.h"
+
+// ZisK terminates on ecall with a7=93
+#define CAUSE_EXIT 93
+
+TEXT cpuinit(SB),NOSPLIT|NOFRAME,$0
+
+// func sys_exit(code int32)
+TEXT ·sys_exit(SB),NOSPLIT,$0-4
+	MOVW	code+0(FP), A0
+	MOV	$CAUSE_EXIT, A7
+	ECALL
+	RET